@@ -0,0 +1,151 @@
+// Command apstub is a minimal local ActivityPub server for trying out
+// federation without a real fediverse instance. It serves one actor whose
+// inbox verifies and logs every activity delivered to it, and can follow
+// or unfollow an author.
+//
+//	go run ./cmdn/apstub -follow 'http://localhost:8003/ap/actor?id=1'
+//	go run ./cmdn/apstub -undo -follow 'http://localhost:8003/ap/actor?id=1'
+package main
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/activitypub"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/safehttp"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8090", "address to listen on")
+	follow := flag.String("follow", "", "actor URL of an author to follow once started")
+	undo := flag.Bool("undo", false, "unfollow the -follow author instead")
+	flag.Parse()
+
+	base := "http://" + *addr
+	actorID := base + "/actor"
+	keyID := actorID + "#main-key"
+
+	privatePEM, publicPEM, err := activitypub.GenerateKey()
+	if err != nil {
+		log.Fatalf("failed to generate key: %v", err)
+	}
+	key, err := activitypub.ParsePrivateKey(privatePEM)
+	if err != nil {
+		log.Fatalf("failed to parse key: %v", err)
+	}
+	// The stub talks to servers on localhost.
+	client := activitypub.NewClient(safehttp.NewClient(10*time.Second, 1<<20, true))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/actor", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", activitypub.ContentType)
+		json.NewEncoder(w).Encode(activitypub.Actor{
+			Context:           activitypub.Context,
+			ID:                actorID,
+			Type:              "Person",
+			PreferredUsername: "stub",
+			Inbox:             base + "/inbox",
+			PublicKey:         &activitypub.PublicKey{ID: keyID, Owner: actorID, PublicKeyPem: publicPEM},
+		})
+	})
+	mux.HandleFunc("/inbox", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if err := verify(client, r, body); err != nil {
+			log.Printf("rejected delivery: %v", err)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		var activity activitypub.Activity
+		if err := json.Unmarshal(body, &activity); err != nil {
+			http.Error(w, "invalid activity", http.StatusBadRequest)
+			return
+		}
+		log.Printf("received %s from %s: %s", activity.Type, activity.Actor, body)
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	go func() {
+		log.Printf("ActivityPub stub listening on %s as %s", *addr, actorID)
+		if err := http.ListenAndServe(*addr, mux); err != nil {
+			log.Fatalf("HTTP server failed: %v", err)
+		}
+	}()
+
+	if *follow != "" {
+		// Give the server a moment, the author's inbox fetches our actor.
+		time.Sleep(500 * time.Millisecond)
+		if err := sendFollow(client, *follow, actorID, keyID, key, *undo); err != nil {
+			log.Fatalf("follow failed: %v", err)
+		}
+	}
+	select {}
+}
+
+// verify checks a delivery's signature against the key of the actor named
+// in its keyId.
+func verify(client *activitypub.Client, r *http.Request, body []byte) error {
+	keyID, err := activitypub.SignatureKeyID(r)
+	if err != nil {
+		return err
+	}
+	actorURL, _, _ := strings.Cut(keyID, "#")
+	actor, err := client.FetchActor(actorURL)
+	if err != nil {
+		return err
+	}
+	if actor.PublicKey == nil {
+		return activitypub.ErrInvalidSignature
+	}
+	pub, err := activitypub.ParsePublicKey(actor.PublicKey.PublicKeyPem)
+	if err != nil {
+		return err
+	}
+	return activitypub.Verify(r, body, pub)
+}
+
+func sendFollow(client *activitypub.Client, target, actorID, keyID string, key *rsa.PrivateKey, undo bool) error {
+	author, err := client.FetchActor(target)
+	if err != nil {
+		return err
+	}
+
+	activity := activitypub.Activity{
+		Context: activitypub.Context,
+		ID:      actorID + "#follows/" + author.ID,
+		Type:    "Follow",
+		Actor:   actorID,
+	}
+	activity.SetObject(author.ID)
+	if undo {
+		follow := activity
+		follow.Context = nil
+		activity = activitypub.Activity{
+			Context: activitypub.Context,
+			ID:      actorID + "#undo/" + author.ID,
+			Type:    "Undo",
+			Actor:   actorID,
+		}
+		activity.SetObject(follow)
+	}
+
+	payload, err := json.Marshal(activity)
+	if err != nil {
+		return err
+	}
+	if err := client.Deliver(author.Inbox, payload, keyID, key); err != nil {
+		return err
+	}
+	log.Printf("sent %s to %s", activity.Type, author.Inbox)
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
 	"github.com/Hamiduzzaman96/Blog-Service/pkg/jwt"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/redis"
 	"github.com/Hamiduzzaman96/Blog-Service/proto/authorpb"
 	"google.golang.org/grpc"
 	"gorm.io/driver/postgres"
@@ -52,10 +53,19 @@ func main() {
 	if err := authorRepo.Migrate(); err != nil {
 		log.Fatalf("failed to migrate Author table: %v", err)
 	}
+	redisClient, err := redis.New(cfg.Redis.Host+":"+cfg.Redis.Port, cfg.Redis.Password, cfg.Redis.DB, 2*time.Hour)
+	if err != nil {
+		log.Fatalf("failed to connect redis: %v", err)
+	}
+	defer redisClient.Close()
+
 	jwtSvc := jwt.NewService(cfg.JWT.Secret, cfg.JWT.AccessTokenExp, cfg.JWT.RefreshTokenExp)
+	authMiddleware := middleware.NewAuthMiddleware(jwtSvc, redisClient)
 	authorUsecase := usecase.NewAuthorUsecase(userRepo, authorRepo)
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authMiddleware.UnaryOptionalAuth()),
+	)
 	authorGRPCHandler := grpcHandler.NewAuthorHandler(authorUsecase)
 	authorpb.RegisterAuthorServiceServer(grpcServer, authorGRPCHandler)
 
@@ -72,7 +82,6 @@ func main() {
 
 	mux := http.NewServeMux()
 	authorHTTPHandler := httpHandler.NewAuthorHandler(authorUsecase)
-	authMiddleware := middleware.NewAuthMiddleware(jwtSvc)
 
 	mux.Handle("/become-author", authMiddleware.RequireAuth(http.HandlerFunc(authorHTTPHandler.BecomeAuthor)))
 
@@ -12,13 +12,18 @@ import (
 	"time"
 
 	"github.com/Hamiduzzaman96/Blog-Service/config"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
 	grpcHandler "github.com/Hamiduzzaman96/Blog-Service/internal/handler/grpc"
 	httpHandler "github.com/Hamiduzzaman96/Blog-Service/internal/handler/http"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/middleware"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/activitypub"
 	"github.com/Hamiduzzaman96/Blog-Service/pkg/jwt"
 	"github.com/Hamiduzzaman96/Blog-Service/pkg/rabbitmq"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/redis"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/safehttp"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/webmention"
 	"github.com/Hamiduzzaman96/Blog-Service/proto/blogpb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -48,13 +53,45 @@ func main() {
 
 	blogRepo := repository.NewBlogRepository(db)
 	authorRepo := repository.NewAuthorRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	eventRepo := repository.NewPostEventRepository(db)
+	autosaveRepo := repository.NewAutosaveRepository(db)
+	publicationRepo := repository.NewPublicationRepository(db)
+	federationRepo := repository.NewFederationRepository(db)
+	webmentionRepo := repository.NewWebmentionRepository(db)
+	mediaRepo := repository.NewMediaRepository(db)
 
 	if err := blogRepo.Migrate(); err != nil {
 		log.Fatalf("failed to migrate blog table: %v", err)
 	}
+	if err := commentRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate comment table: %v", err)
+	}
+	if err := eventRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate post event table: %v", err)
+	}
+	if err := autosaveRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate autosave table: %v", err)
+	}
 	if err := authorRepo.Migrate(); err != nil {
 		log.Fatalf("failed to migrate author table: %v", err)
 	}
+	if err := userRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate user table: %v", err)
+	}
+	if err := publicationRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate publication tables: %v", err)
+	}
+	if err := federationRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate federation tables: %v", err)
+	}
+	if err := webmentionRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate webmention tables: %v", err)
+	}
+	if err := mediaRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate media table: %v", err)
+	}
 
 	mqClient, err := rabbitmq.New(
 		cfg.RabbitMQ.Host,
@@ -68,14 +105,97 @@ func main() {
 		log.Fatalf("failed to connect RabbitMQ: %v", err)
 	}
 
+	redisClient, err := redis.New(cfg.Redis.Host+":"+cfg.Redis.Port, cfg.Redis.Password, cfg.Redis.DB, 2*time.Hour)
+	if err != nil {
+		log.Fatalf("failed to connect redis: %v", err)
+	}
+	defer redisClient.Close()
+
 	blogUsecase := usecase.NewBlogUsecase(
 		blogRepo,
 		authorRepo,
+		userRepo,
+		eventRepo,
+		autosaveRepo,
+		publicationRepo,
 		mqClient,
+		redisClient,
+		cfg.Blog,
+	)
+	publicationUsecase := usecase.NewPublicationUsecase(publicationRepo, cfg.Blog)
+	mediaUsecase := usecase.NewMediaUsecase(mediaRepo, userRepo, cfg.Blog)
+	// Remote actors, inboxes and webmention sources are untrusted URLs.
+	fetchClient := safehttp.NewClient(10*time.Second, 1<<20, cfg.Blog.AllowPrivateFetch)
+	federationUsecase := usecase.NewFederationUsecase(
+		federationRepo,
+		authorRepo,
+		blogRepo,
+		activitypub.NewClient(fetchClient),
+		blogUsecase,
+		cfg.Blog,
 	)
+	webmentionUsecase := usecase.NewWebmentionUsecase(
+		webmentionRepo,
+		commentRepo,
+		blogUsecase,
+		webmention.NewClient(fetchClient),
+		redisClient,
+	)
+
+	postWatcher := usecase.NewPostWatcher(eventRepo, authorRepo)
+	if err := mqClient.Subscribe(
+		[]string{domain.EventPostCreated, domain.EventPostUpdated, domain.EventPostDeleted, domain.EventPostPublished},
+		postWatcher.Dispatch,
+	); err != nil {
+		log.Fatalf("failed to subscribe to post events: %v", err)
+	}
+	if err := mqClient.Consume(cfg.RabbitMQ.FederationQueue, domain.EventPostPublished, federationUsecase.HandlePostEvent); err != nil {
+		log.Fatalf("failed to consume federation queue: %v", err)
+	}
+	if err := mqClient.Consume(cfg.RabbitMQ.WebmentionQueue, domain.EventPostPublished, webmentionUsecase.HandlePostEvent); err != nil {
+		log.Fatalf("failed to consume webmention queue: %v", err)
+	}
+	go func() {
+		ticker := time.NewTicker(10 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := federationUsecase.DeliverPending(); err != nil {
+					log.Printf("activitypub delivery failed: %v", err)
+				}
+				if _, err := webmentionUsecase.SendPending(); err != nil {
+					log.Printf("sending webmentions failed: %v", err)
+				}
+				if _, err := webmentionUsecase.VerifyPending(); err != nil {
+					log.Printf("verifying webmentions failed: %v", err)
+				}
+			}
+		}
+	}()
 
-	grpcServer := grpc.NewServer()
-	blogGRPCHandler := grpcHandler.NewBlogHandler(blogUsecase)
+	jwtSvc := jwt.NewService(cfg.JWT.Secret, cfg.JWT.AccessTokenExp, cfg.JWT.RefreshTokenExp)
+	authMiddleware := middleware.NewAuthMiddleware(jwtSvc, redisClient)
+
+	blogGRPCHandler := grpcHandler.NewBlogHandler(blogUsecase, publicationUsecase, postWatcher)
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			authMiddleware.UnaryOptionalAuth(),
+			// Editing, publishing, locking, autosaving and reviewing are
+			// checked per post by the usecase, as editors may lack
+			// post:create.
+			middleware.UnaryRequirePermission(map[string]string{
+				blogpb.BlogService_CreatePost_FullMethodName:      domain.PermPostCreate,
+				blogpb.BlogService_SetTranslation_FullMethodName:  domain.PermPostCreate,
+				blogpb.BlogService_DeletePost_FullMethodName:      domain.PermPostCreate,
+				blogpb.BlogService_SubmitForReview_FullMethodName: domain.PermPostCreate,
+			}),
+			blogGRPCHandler.UnaryPublication(),
+		),
+		grpc.ChainStreamInterceptor(authMiddleware.StreamOptionalAuth(), blogGRPCHandler.StreamPublication()),
+	)
 	blogpb.RegisterBlogServiceServer(grpcServer, blogGRPCHandler)
 	reflection.Register(grpcServer)
 
@@ -90,19 +210,118 @@ func main() {
 			log.Fatalf("gRPC server failed: %v", err)
 		}
 	}()
-	jwtSvc := jwt.NewService(cfg.JWT.Secret, cfg.JWT.AccessTokenExp, cfg.JWT.RefreshTokenExp)
 	mux := http.NewServeMux()
-	blogHTTPHandler := httpHandler.NewBolgHandler(blogUsecase)
-	authMiddleware := middleware.NewAuthMiddleware(jwtSvc)
+	blogHTTPHandler := httpHandler.NewBolgHandler(blogUsecase, publicationUsecase)
+	publicationHTTPHandler := httpHandler.NewPublicationHandler(publicationUsecase)
 
 	mux.Handle(
 		"/blog/create",
-		authMiddleware.RequireAuth(http.HandlerFunc(blogHTTPHandler.CreatePost)),
+		authMiddleware.RequirePermission(domain.PermPostCreate, http.HandlerFunc(blogHTTPHandler.CreatePost)),
+	)
+	mux.Handle("/blog/post", authMiddleware.OptionalAuth(http.HandlerFunc(blogHTTPHandler.GetPost)))
+	mux.Handle("/blog/posts", authMiddleware.OptionalAuth(http.HandlerFunc(blogHTTPHandler.ListPosts)))
+	mux.Handle("/blog/meta", authMiddleware.OptionalAuth(http.HandlerFunc(blogHTTPHandler.PostMeta)))
+	mux.HandleFunc("/oembed", blogHTTPHandler.OEmbed)
+	mux.Handle("/micropub", authMiddleware.RequireAuth(http.HandlerFunc(blogHTTPHandler.Micropub)))
+	// Editing is open to anyone who may edit the post, which the usecase
+	// decides: its author or an editor, who may lack post:create.
+	mux.Handle(
+		"/blog/update",
+		authMiddleware.RequireAuth(http.HandlerFunc(blogHTTPHandler.UpdatePost)),
+	)
+	mux.Handle(
+		"/blog/delete",
+		authMiddleware.RequirePermission(domain.PermPostCreate, http.HandlerFunc(blogHTTPHandler.DeletePost)),
+	)
+	mux.Handle(
+		"/blog/review/submit",
+		authMiddleware.RequirePermission(domain.PermPostCreate, http.HandlerFunc(blogHTTPHandler.SubmitForReview)),
+	)
+	mux.Handle(
+		"/blog/review",
+		authMiddleware.RequireAuth(http.HandlerFunc(blogHTTPHandler.ReviewPost)),
+	)
+	mux.Handle(
+		"/blog/review/queue",
+		authMiddleware.RequireAuth(http.HandlerFunc(blogHTTPHandler.ReviewQueue)),
+	)
+	mux.Handle(
+		"/blog/review/comments",
+		authMiddleware.RequireAuth(http.HandlerFunc(blogHTTPHandler.ReviewComments)),
+	)
+	mux.Handle(
+		"/blog/publish",
+		authMiddleware.RequireAuth(http.HandlerFunc(blogHTTPHandler.PublishPost)),
+	)
+	mux.Handle(
+		"/blog/lock",
+		authMiddleware.RequireAuth(http.HandlerFunc(blogHTTPHandler.GetEditLock)),
+	)
+	mux.Handle(
+		"/blog/lock/acquire",
+		authMiddleware.RequireAuth(http.HandlerFunc(blogHTTPHandler.AcquireEditLock)),
+	)
+	mux.Handle(
+		"/blog/lock/renew",
+		authMiddleware.RequireAuth(http.HandlerFunc(blogHTTPHandler.RenewEditLock)),
+	)
+	mux.Handle(
+		"/blog/lock/release",
+		authMiddleware.RequireAuth(http.HandlerFunc(blogHTTPHandler.ReleaseEditLock)),
+	)
+	mux.Handle(
+		"/blog/autosave",
+		authMiddleware.RequireAuth(http.HandlerFunc(blogHTTPHandler.Autosave)),
+	)
+	mux.Handle(
+		"/blog/translation",
+		authMiddleware.RequirePermission(domain.PermPostCreate, http.HandlerFunc(blogHTTPHandler.SetTranslation)),
+	)
+	mux.Handle(
+		"/blog/import",
+		authMiddleware.RequirePermission(domain.PermPostCreate, http.HandlerFunc(blogHTTPHandler.ImportMarkdown)),
+	)
+	mux.Handle(
+		"/blog/export",
+		authMiddleware.RequirePermission(domain.PermPostCreate, http.HandlerFunc(blogHTTPHandler.ExportMarkdown)),
+	)
+
+	federationHTTPHandler := httpHandler.NewFederationHandler(federationUsecase)
+	mux.HandleFunc("/.well-known/webfinger", federationHTTPHandler.WebFinger)
+	mux.HandleFunc("/ap/actor", federationHTTPHandler.Actor)
+	mux.HandleFunc("/ap/inbox", federationHTTPHandler.Inbox)
+	mux.HandleFunc("/ap/outbox", federationHTTPHandler.Outbox)
+	mux.HandleFunc("/ap/followers", federationHTTPHandler.Followers)
+	mux.HandleFunc("/ap/post", federationHTTPHandler.Article)
+
+	mediaHTTPHandler := httpHandler.NewMediaHandler(mediaUsecase)
+	mux.Handle("GET /media", http.HandlerFunc(mediaHTTPHandler.Serve))
+	mux.Handle(
+		"POST /media",
+		authMiddleware.RequirePermission(domain.PermPostCreate, http.HandlerFunc(mediaHTTPHandler.Upload)),
+	)
+
+	webmentionHTTPHandler := httpHandler.NewWebmentionHandler(webmentionUsecase)
+	mux.HandleFunc("/webmention", webmentionHTTPHandler.Webmention)
+
+	mux.Handle(
+		"/publications",
+		authMiddleware.RequireAuth(http.HandlerFunc(publicationHTTPHandler.CreatePublication)),
+	)
+	mux.Handle("/publication", authMiddleware.OptionalAuth(http.HandlerFunc(publicationHTTPHandler.Publication)))
+	mux.Handle(
+		"/publication/domain/verify",
+		authMiddleware.RequireAuth(http.HandlerFunc(publicationHTTPHandler.VerifyDomain)),
+	)
+	mux.Handle(
+		"/publication/members",
+		authMiddleware.RequireAuth(http.HandlerFunc(publicationHTTPHandler.Members)),
 	)
 
 	httpServer := &http.Server{
-		Addr:    cfg.BlogService.HTTPPort,
-		Handler: mux,
+		Addr: cfg.BlogService.HTTPPort,
+		// Every route is served for the publication of the request.
+		Handler: blogHTTPHandler.WithPublication(mux),
 	}
 
 	go func() {
@@ -122,6 +341,16 @@ func main() {
 		log.Fatalf("HTTP shutdown failed: %v", err)
 	}
 
-	grpcServer.GracefulStop()
+	// WatchPosts streams only end when clients hang up, so don't wait forever.
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		grpcServer.Stop()
+	}
 	log.Println("Blog Service stopped")
 }
@@ -0,0 +1,90 @@
+// Command markdown imports and exports an author's posts as a zip of
+// markdown files with YAML front matter.
+//
+//	go run ./cmdn/markdown import -user 3 -file posts.zip
+//	go run ./cmdn/markdown export -user 3 -out posts.zip
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Hamiduzzaman96/Blog-Service/config"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	cmd := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	userID := cmd.Uint("user", 0, "user ID of the author who owns the posts")
+	file := cmd.String("file", "", "zip archive to import")
+	out := cmd.String("out", "posts.zip", "zip archive to write on export")
+	cmd.Parse(os.Args[2:])
+
+	if *userID == 0 {
+		log.Fatal("-user is required")
+	}
+
+	cfg := config.Load()
+	dsn := fmt.Sprintf(
+		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+		cfg.Postgres.Host, cfg.Postgres.User, cfg.Postgres.Password,
+		cfg.Postgres.DB, cfg.Postgres.Port, cfg.Postgres.SSLMode,
+	)
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("failed to connect postgres: %v", err)
+	}
+
+	blogRepo := repository.NewBlogRepository(db)
+	authorRepo := repository.NewAuthorRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	if err := blogRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate blog table: %v", err)
+	}
+
+	// Imports and exports never publish events, so no RabbitMQ connection is needed.
+	blogUsecase := usecase.NewBlogUsecase(blogRepo, authorRepo, userRepo, nil, nil, nil, nil, nil, cfg.Blog)
+
+	switch os.Args[1] {
+	case "import":
+		if *file == "" {
+			log.Fatal("-file is required")
+		}
+		archive, err := os.ReadFile(*file)
+		if err != nil {
+			log.Fatalf("failed to read archive: %v", err)
+		}
+		count, err := blogUsecase.ImportMarkdown(*userID, archive)
+		if err != nil {
+			log.Fatalf("import failed, no posts were created: %v", err)
+		}
+		log.Printf("imported %d posts", count)
+
+	case "export":
+		archive, err := blogUsecase.ExportMarkdown(*userID)
+		if err != nil {
+			log.Fatalf("export failed: %v", err)
+		}
+		if err := os.WriteFile(*out, archive, 0o644); err != nil {
+			log.Fatalf("failed to write archive: %v", err)
+		}
+		log.Printf("exported posts to %s", *out)
+
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: markdown import|export -user <id> [-file posts.zip] [-out posts.zip]")
+	os.Exit(2)
+}
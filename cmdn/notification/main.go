@@ -12,10 +12,13 @@ import (
 	"time"
 
 	"github.com/Hamiduzzaman96/Blog-Service/config"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
 	grpcHandler "github.com/Hamiduzzaman96/Blog-Service/internal/handler/grpc"
 	httpHandler "github.com/Hamiduzzaman96/Blog-Service/internal/handler/http"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/rabbitmq"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/redis"
 	"github.com/Hamiduzzaman96/Blog-Service/proto/notificationpb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -44,12 +47,60 @@ func main() {
 	}
 
 	notifRepo := repository.NewNotificationRepository(db)
+	subscriberRepo := repository.NewSubscriberRepository(db)
+	outboxRepo := repository.NewEmailOutboxRepository(db)
 
 	if err := notifRepo.Migrate(); err != nil {
 		log.Fatalf("failed to migrate Notification table: %v", err)
 	}
+	if err := subscriberRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate Subscriber table: %v", err)
+	}
+	if err := outboxRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate email outbox table: %v", err)
+	}
+
+	if cfg.Newsletter.Secret == "" {
+		log.Fatal("NEWSLETTER_SECRET is required to sign unsubscribe links")
+	}
+
+	redisClient, err := redis.New(cfg.Redis.Host+":"+cfg.Redis.Port, cfg.Redis.Password, cfg.Redis.DB, 2*time.Hour)
+	if err != nil {
+		log.Fatalf("failed to connect redis: %v", err)
+	}
+	defer redisClient.Close()
+
+	notifUsecase := usecase.NewNotificationUsecase(notifRepo, outboxRepo)
+	newsletterUsecase := usecase.NewNewsletterUsecase(
+		subscriberRepo,
+		outboxRepo,
+		repository.NewAuthorRepository(db),
+		redisClient,
+		cfg.Newsletter,
+		cfg.Blog,
+	)
 
-	notifUsecase := usecase.NewNotificationUsecase(notifRepo)
+	mqClient, err := rabbitmq.New(
+		cfg.RabbitMQ.Host,
+		cfg.RabbitMQ.Port,
+		cfg.RabbitMQ.User,
+		cfg.RabbitMQ.Password,
+		cfg.RabbitMQ.Exchange,
+		cfg.RabbitMQ.ExchangeType,
+	)
+	if err != nil {
+		log.Fatalf("failed to connect RabbitMQ: %v", err)
+	}
+	defer mqClient.Close()
+
+	for _, key := range []string{"blog.review.*", domain.EventPostPublished} {
+		if err := mqClient.Consume(cfg.RabbitMQ.NotificationQueue, key, notifUsecase.HandlePostEvent); err != nil {
+			log.Fatalf("failed to consume %s events: %v", key, err)
+		}
+	}
+	if err := mqClient.Consume(cfg.RabbitMQ.NewsletterQueue, domain.EventPostPublished, newsletterUsecase.HandlePostEvent); err != nil {
+		log.Fatalf("failed to consume %s events: %v", domain.EventPostPublished, err)
+	}
 
 	grpcServer := grpc.NewServer()
 	notifGRPCHandler := grpcHandler.NewNotificationHandler(notifUsecase)
@@ -70,7 +121,12 @@ func main() {
 	mux := http.NewServeMux()
 	notifHTTPHandler := httpHandler.NewNotificationHandler(notifUsecase)
 
+	newsletterHTTPHandler := httpHandler.NewNewsletterHandler(newsletterUsecase)
+
 	mux.Handle("/send-notification", http.HandlerFunc(notifHTTPHandler.SendNotification))
+	mux.HandleFunc("/newsletter/subscribe", newsletterHTTPHandler.Subscribe)
+	mux.HandleFunc("/newsletter/confirm", newsletterHTTPHandler.Confirm)
+	mux.HandleFunc("/newsletter/unsubscribe", newsletterHTTPHandler.Unsubscribe)
 
 	httpServer := &http.Server{
 		Addr:    cfg.NotificationService.HTTPPort,
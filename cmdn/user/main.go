@@ -12,9 +12,11 @@ import (
 
 	"github.com/Hamiduzzaman96/Blog-Service/config"
 	"github.com/Hamiduzzaman96/Blog-Service/pkg/jwt"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/payment"
 	"github.com/Hamiduzzaman96/Blog-Service/pkg/redis"
 	"github.com/Hamiduzzaman96/Blog-Service/proto/userpb"
 
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
 	grpcHandler "github.com/Hamiduzzaman96/Blog-Service/internal/handler/grpc"
 	httpHandler "github.com/Hamiduzzaman96/Blog-Service/internal/handler/http"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/middleware"
@@ -30,6 +32,10 @@ import (
 func main() {
 	cfg := config.Load()
 
+	if cfg.Account.Secret == "" {
+		log.Fatal("ACCOUNT_SECRET is required to sign verification links")
+	}
+
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
@@ -49,8 +55,34 @@ func main() {
 	if err := userRepo.Migrate(); err != nil {
 		log.Fatalf("failed to migrate User table: %v", err)
 	}
+	mfaRepo := repository.NewMFARepository(db)
+	if err := mfaRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate MFA tables: %v", err)
+	}
+	entitlementRepo := repository.NewEntitlementRepository(db)
+	if err := entitlementRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate Entitlement table: %v", err)
+	}
+
+	// Account emails go through the notification service's outbox.
+	notifRepo := repository.NewNotificationRepository(db)
+	if err := notifRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate Notification table: %v", err)
+	}
+	outboxRepo := repository.NewEmailOutboxRepository(db)
+	if err := outboxRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate EmailOutbox table: %v", err)
+	}
 
-	redisClient, err := redis.New(cfg.Redis.Host+":"+cfg.Redis.Port, cfg.Redis.Password, cfg.Redis.DB, 2*time.Hour)
+	// Access tokens stay listed in Redis for as long as they are valid.
+	tokenTTL := time.Duration(cfg.JWT.AccessTokenExp) * time.Minute
+	// Avatars are media uploaded through the blog service.
+	mediaRepo := repository.NewMediaRepository(db)
+	if err := mediaRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate Media table: %v", err)
+	}
+
+	redisClient, err := redis.New(cfg.Redis.Host+":"+cfg.Redis.Port, cfg.Redis.Password, cfg.Redis.DB, tokenTTL)
 	if err != nil {
 		log.Fatalf("failed to connect redis: %v", err)
 	}
@@ -58,10 +90,28 @@ func main() {
 
 	jwtSvc := jwt.NewService(cfg.JWT.Secret, cfg.JWT.AccessTokenExp, cfg.JWT.RefreshTokenExp)
 
-	userUsecase := usecase.NewUserUsecase(userRepo, jwtSvc, redisClient)
-
-	grpcServer := grpc.NewServer()
-	userGRPCHandler := grpcHandler.NewUserHandler(userUsecase)
+	// No real payment provider is integrated yet. The fake one, which marks
+	// every checkout as paid, must be switched on explicitly.
+	var payments payment.Provider
+	if cfg.Payment.FakeProvider {
+		log.Println("PAYMENT_FAKE_PROVIDER is on: checkouts are granted without payment")
+		payments = payment.NewFake()
+	}
+	membershipUsecase := usecase.NewMembershipUsecase(entitlementRepo, userRepo, payments, redisClient)
+	notifUsecase := usecase.NewNotificationUsecase(notifRepo, outboxRepo)
+	userUsecase := usecase.NewUserUsecase(userRepo, mfaRepo, membershipUsecase, notifUsecase, jwtSvc, redisClient, cfg.Account)
+	profileUsecase := usecase.NewProfileUsecase(userRepo, mediaRepo, cfg.Blog)
+
+	authMiddleware := middleware.NewAuthMiddleware(jwtSvc, redisClient)
+
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		authMiddleware.UnaryOptionalAuth(),
+		middleware.UnaryRequirePermission(map[string]string{
+			userpb.UserService_SetRoles_FullMethodName: domain.PermRoleAssign,
+			userpb.UserService_BanUser_FullMethodName:  domain.PermUserBan,
+		}),
+	))
+	userGRPCHandler := grpcHandler.NewUserHandler(userUsecase, profileUsecase)
 	userpb.RegisterUserServiceServer(grpcServer, userGRPCHandler)
 	reflection.Register(grpcServer)
 
@@ -78,12 +128,34 @@ func main() {
 
 	mux := http.NewServeMux()
 	userHTTPHandler := httpHandler.NewUserHandler(userUsecase)
-	authMiddleware := middleware.NewAuthMiddleware(jwtSvc)
 
 	mux.Handle("/register", http.HandlerFunc(userHTTPHandler.Register))
 	mux.Handle("/login", http.HandlerFunc(userHTTPHandler.Login))
+	mux.Handle("/login/mfa", http.HandlerFunc(userHTTPHandler.LoginMFA))
+	mux.Handle("/mfa/totp", authMiddleware.RequireAuth(http.HandlerFunc(userHTTPHandler.TOTP)))
+	mux.Handle("/mfa/totp/confirm", authMiddleware.RequireAuth(http.HandlerFunc(userHTTPHandler.ConfirmTOTP)))
+	mux.Handle("/refresh", http.HandlerFunc(userHTTPHandler.Refresh))
+	mux.Handle("/logout", authMiddleware.RequireAuth(http.HandlerFunc(userHTTPHandler.Logout)))
+	mux.Handle("/logout/all", authMiddleware.RequireAuth(http.HandlerFunc(userHTTPHandler.LogoutEverywhere)))
+	mux.Handle("/verify-email", http.HandlerFunc(userHTTPHandler.VerifyEmail))
+	mux.Handle("/verify-email/resend", authMiddleware.RequireAuth(http.HandlerFunc(userHTTPHandler.ResendVerification)))
+	mux.Handle("/password/forgot", http.HandlerFunc(userHTTPHandler.ForgotPassword))
+	mux.Handle("/password/reset", http.HandlerFunc(userHTTPHandler.ResetPassword))
+	profileHTTPHandler := httpHandler.NewProfileHandler(profileUsecase)
+	mux.Handle("/profile", authMiddleware.OptionalAuth(http.HandlerFunc(profileHTTPHandler.Profile)))
 	mux.Handle("/promote", authMiddleware.RequireAuth(http.HandlerFunc(userHTTPHandler.PromoteToAuthor)))
 
+	membershipHTTPHandler := httpHandler.NewMembershipHandler(membershipUsecase)
+	mux.Handle("/membership", authMiddleware.RequireAuth(http.HandlerFunc(membershipHTTPHandler.Membership)))
+	if payments != nil {
+		mux.Handle("/membership/checkout", authMiddleware.RequireAuth(http.HandlerFunc(membershipHTTPHandler.Checkout)))
+		mux.Handle("/membership/complete", authMiddleware.RequireAuth(http.HandlerFunc(membershipHTTPHandler.CompleteCheckout)))
+	}
+	mux.Handle("/admin/login/unlock", authMiddleware.RequirePermission(domain.PermUserUnlock, http.HandlerFunc(userHTTPHandler.UnlockLogin)))
+	mux.Handle("/admin/roles", authMiddleware.RequirePermission(domain.PermRoleAssign, http.HandlerFunc(userHTTPHandler.Roles)))
+	mux.Handle("/admin/ban", authMiddleware.RequirePermission(domain.PermUserBan, http.HandlerFunc(userHTTPHandler.Ban)))
+	mux.Handle("/admin/entitlements", authMiddleware.RequirePermission(domain.PermMembershipManage, http.HandlerFunc(membershipHTTPHandler.Entitlements)))
+
 	httpServer := &http.Server{
 		Addr:    cfg.UserService.HTTPPort,
 		Handler: mux,
@@ -0,0 +1,96 @@
+// Command wmstub is a minimal local site for trying out Webmentions without
+// a real remote site. Its /page advertises a Webmention endpoint that
+// verifies and logs every mention it receives, so a post linking to
+// http://localhost:8091/page gets its mention sent there. With -mention it
+// also serves a /source page linking to a post and sends the post's blog a
+// webmention for it; -endpoint skips discovery, for post URLs no local
+// server serves.
+//
+//	go run ./cmdn/wmstub
+//	go run ./cmdn/wmstub -mention http://localhost:8003/posts/hello-world -endpoint http://localhost:8003/webmention
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/safehttp"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/webmention"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8091", "address to listen on")
+	mention := flag.String("mention", "", "post URL to link to from /source and send a webmention for")
+	endpoint := flag.String("endpoint", "", "webmention endpoint of the -mention post, discovered when empty")
+	flag.Parse()
+
+	base := "http://" + *addr
+	// The stub talks to servers on localhost.
+	client := webmention.NewClient(safehttp.NewClient(10*time.Second, 1<<20, true))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<%s/webmention>; rel="webmention"`, base))
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<!doctype html><title>Stub page</title><p>Link here to send this page a webmention.</p>`)
+	})
+	mux.HandleFunc("/webmention", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		source, target := r.FormValue("source"), r.FormValue("target")
+		w.WriteHeader(http.StatusAccepted)
+
+		go func() {
+			if _, err := client.Verify(source, target); err != nil {
+				log.Printf("rejected webmention %s -> %s: %v", source, target, err)
+				return
+			}
+			log.Printf("received webmention %s -> %s", source, target)
+		}()
+	})
+	mux.HandleFunc("/source", func(w http.ResponseWriter, r *http.Request) {
+		if *mention == "" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, `<!doctype html><title>Stub source</title><p>I read <a href="%s">this post</a>.</p>`,
+			html.EscapeString(*mention))
+	})
+
+	go func() {
+		log.Printf("Webmention stub listening on %s", *addr)
+		if err := http.ListenAndServe(*addr, mux); err != nil {
+			log.Fatalf("HTTP server failed: %v", err)
+		}
+	}()
+
+	if *mention != "" {
+		// Give the server a moment, the blog fetches /source to verify.
+		time.Sleep(500 * time.Millisecond)
+		if err := send(client, *endpoint, base+"/source", *mention); err != nil {
+			log.Fatalf("sending webmention failed: %v", err)
+		}
+	}
+	select {}
+}
+
+func send(client *webmention.Client, endpoint, source, target string) error {
+	if endpoint == "" {
+		var err error
+		if endpoint, err = client.Discover(target); err != nil {
+			return err
+		}
+	}
+	if err := client.Send(endpoint, source, target); err != nil {
+		return err
+	}
+	log.Printf("sent webmention %s -> %s to %s", source, target, endpoint)
+	return nil
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/wxr"
+)
+
+// import record kinds
+const (
+	kindUser    = "user"
+	kindPost    = "post"
+	kindComment = "comment"
+)
+
+// importer replays a WXR export through the use cases. Every created object
+// is recorded against its WordPress ID, so a rerun after a failure skips
+// whatever the previous run already imported.
+type importer struct {
+	source   string
+	records  *repository.ImportRecordRepository
+	users    *usecase.UserUsecase
+	authors  *usecase.AuthorUsecase
+	blogs    *usecase.BlogUsecase
+	comments *usecase.CommentUsecase
+
+	userByLogin map[string]uint
+	userByWPID  map[string]uint
+	stats       map[string]int
+}
+
+func (im *importer) run(exp *wxr.Export) error {
+	im.userByLogin = make(map[string]uint)
+	im.userByWPID = make(map[string]uint)
+	im.stats = make(map[string]int)
+
+	for _, a := range exp.Authors {
+		userID, err := im.importAuthor(a)
+		if err != nil {
+			return fmt.Errorf("author %q: %w", a.Login, err)
+		}
+		im.userByLogin[a.Login] = userID
+		im.userByWPID[a.ID] = userID
+	}
+
+	for i := range exp.Items {
+		item := &exp.Items[i]
+		if item.PostType != "post" {
+			continue
+		}
+
+		postID, err := im.importPost(item)
+		if err != nil {
+			return fmt.Errorf("post %s %q: %w", item.PostID, item.Title, err)
+		}
+
+		for j := range item.Comments {
+			if err := im.importComment(postID, &item.Comments[j]); err != nil {
+				return fmt.Errorf("comment %s on post %s: %w", item.Comments[j].ID, item.PostID, err)
+			}
+		}
+	}
+
+	log.Printf("imported %d users, %d posts, %d comments", im.stats[kindUser], im.stats[kindPost], im.stats[kindComment])
+	return nil
+}
+
+func (im *importer) importAuthor(a wxr.Author) (uint, error) {
+	if id, ok, err := im.records.Find(im.source, kindUser, a.Login); err != nil || ok {
+		return id, err
+	}
+
+	email := strings.TrimSpace(a.Email)
+	if email == "" {
+		email = a.Login + "@wordpress.invalid"
+	}
+
+	user, err := im.users.GetByEmail(email)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return 0, err
+	}
+	if errors.Is(err, domain.ErrUserNotFound) {
+		// WXR carries no password hashes; imported users set a new one.
+		user, err = im.users.ImportUser(email, randomPassword())
+		if err != nil {
+			return 0, err
+		}
+		im.stats[kindUser]++
+	}
+
+	if err := im.authors.BecomeAuthor(user.ID, a.DisplayName); err != nil && !errors.Is(err, domain.ErrAlreadyAuthor) {
+		return 0, err
+	}
+
+	return user.ID, im.records.Save(im.source, kindUser, a.Login, user.ID)
+}
+
+func (im *importer) importPost(item *wxr.Item) (uint, error) {
+	if id, ok, err := im.records.Find(im.source, kindPost, item.PostID); err != nil || ok {
+		return id, err
+	}
+
+	userID, ok := im.userByLogin[item.Creator]
+	if !ok {
+		return 0, fmt.Errorf("unknown author %q", item.Creator)
+	}
+
+	published := item.Published()
+	if published.IsZero() {
+		published = time.Now()
+	}
+	post := &domain.BlogPost{
+		Slug:       item.PostName,
+		Title:      item.Title,
+		Content:    item.Content,
+		Tags:       item.Tags(),
+		Categories: item.CategoryNames(),
+		Status:     domain.PostStatusDraft,
+		CreatedAt:  published,
+		UpdatedAt:  published,
+	}
+	switch item.Status {
+	case "publish":
+		post.Status = domain.PostStatusPublished
+		post.PublishedAt = &published
+	case "private":
+		post.Status = domain.PostStatusPublished
+		post.Visibility = domain.VisibilityPrivate
+		post.PublishedAt = &published
+	}
+
+	// The post and its import record are written together.
+	post, err := im.blogs.ImportPost(userID, post, domain.ImportRef{
+		Source:     im.source,
+		Kind:       kindPost,
+		ExternalID: item.PostID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	im.stats[kindPost]++
+
+	return post.ID, nil
+}
+
+func (im *importer) importComment(postID uint, c *wxr.Comment) error {
+	if strings.TrimSpace(c.Content) == "" {
+		return nil
+	}
+	if _, ok, err := im.records.Find(im.source, kindComment, c.ID); err != nil || ok {
+		return err
+	}
+
+	comment := &domain.Comment{
+		BlogID:      postID,
+		UserID:      im.userByWPID[c.UserID],
+		AuthorName:  c.Author,
+		AuthorEmail: c.AuthorEmail,
+		AuthorURL:   c.AuthorURL,
+		Content:     c.Content,
+		Status:      commentStatus(c.Approved),
+		CreatedAt:   c.Published(),
+	}
+	if parent, err := strconv.Atoi(c.Parent); err == nil && parent != 0 {
+		parentID, ok, err := im.records.Find(im.source, kindComment, c.Parent)
+		if err != nil {
+			return err
+		}
+		if ok {
+			comment.ParentID = parentID
+		}
+	}
+
+	// The comment and its import record are written together.
+	if _, err := im.comments.ImportComment(comment, domain.ImportRef{
+		Source:     im.source,
+		Kind:       kindComment,
+		ExternalID: c.ID,
+	}); err != nil {
+		return err
+	}
+	im.stats[kindComment]++
+	return nil
+}
+
+func commentStatus(approved string) string {
+	switch approved {
+	case "1":
+		return domain.CommentStatusApproved
+	case "spam":
+		return domain.CommentStatusSpam
+	default:
+		return domain.CommentStatusPending
+	}
+}
+
+func randomPassword() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,88 @@
+// Command wordpress imports a WordPress WXR export: authors become users
+// with the AUTHOR role, and posts, their tags, categories and comments are
+// created with their original dates and slugs. Progress is recorded in the
+// database, so an interrupted import can simply be run again.
+//
+//	go run ./cmdn/wordpress -file export.xml
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Hamiduzzaman96/Blog-Service/config"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/wxr"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func main() {
+	file := flag.String("file", "", "WXR export file")
+	source := flag.String("source", "", "name identifying the site for resumption (defaults to the export's site URL)")
+	flag.Parse()
+
+	if *file == "" {
+		log.Fatal("-file is required")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("failed to open export: %v", err)
+	}
+	exp, err := wxr.Parse(f)
+	f.Close()
+	if err != nil {
+		log.Fatalf("failed to parse export: %v", err)
+	}
+	if *source == "" {
+		*source = exp.Link
+	}
+
+	cfg := config.Load()
+	dsn := fmt.Sprintf(
+		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+		cfg.Postgres.Host, cfg.Postgres.User, cfg.Postgres.Password,
+		cfg.Postgres.DB, cfg.Postgres.Port, cfg.Postgres.SSLMode,
+	)
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("failed to connect postgres: %v", err)
+	}
+
+	userRepo := repository.NewUserRepository(db)
+	authorRepo := repository.NewAuthorRepository(db)
+	blogRepo := repository.NewBlogRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	recordRepo := repository.NewImportRecordRepository(db)
+
+	for name, migrate := range map[string]func() error{
+		"user":          userRepo.Migrate,
+		"author":        authorRepo.Migrate,
+		"blog":          blogRepo.Migrate,
+		"comment":       commentRepo.Migrate,
+		"import record": recordRepo.Migrate,
+	} {
+		if err := migrate(); err != nil {
+			log.Fatalf("failed to migrate %s table: %v", name, err)
+		}
+	}
+
+	// Imported content is historical, so no events are published and the
+	// user use case never issues tokens; RabbitMQ and Redis are not needed.
+	im := &importer{
+		source:   *source,
+		records:  recordRepo,
+		users:    usecase.NewUserUsecase(userRepo, nil, nil, nil, nil, nil, cfg.Account),
+		authors:  usecase.NewAuthorUsecase(userRepo, authorRepo),
+		blogs:    usecase.NewBlogUsecase(blogRepo, authorRepo, userRepo, nil, nil, nil, nil, nil, cfg.Blog),
+		comments: usecase.NewCommentUsecase(commentRepo, blogRepo),
+	}
+
+	if err := im.run(exp); err != nil {
+		log.Fatalf("import stopped: %v (run again to resume)", err)
+	}
+}
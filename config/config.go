@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -44,6 +45,55 @@ type RabbitMQConfig struct {
 	ExchangeType          string
 	BlogCreatedRoutingKey string
 	NotificationQueue     string
+	NewsletterQueue       string
+	FederationQueue       string
+	WebmentionQueue       string
+}
+
+type BlogConfig struct {
+	DefaultLocale  string
+	LocaleFallback []string
+	RequireReview  bool   // posts must be approved by an editor before publishing
+	EditLockTTLSec int    // lifetime of an edit lock between heartbeats
+	AutosaveSec    int    // minimum interval between two autosaves of a post
+	PublicURL      string // base URL of the public site, posts live at /posts/{slug}
+	SiteName       string
+	OEmbedURL      string // public address of the /oembed endpoint
+	WebmentionURL  string // public address of the /webmention endpoint
+	MediaURL       string // public address of the /media endpoint
+
+	// AllowPrivateFetch lets federation and webmentions reach loopback and
+	// private addresses. For development against local servers only.
+	AllowPrivateFetch bool
+}
+
+type AccountConfig struct {
+	Secret            string // signs email verification links
+	PublicURL         string // base URL of the user service, which emailed links point to
+	ResetTTLMin       int    // lifetime of a password reset link
+	VerifyTTLHours    int    // lifetime of an email verification link
+	ResendIntervalSec int    // minimum time between verification emails to a user
+	TOTPIssuer        string // name authenticator apps list accounts under
+
+	// Failed logins within LoginWindowMin slow further attempts down from
+	// LoginBackoffAfter failures on, and lock the account or the client
+	// address for LoginLockoutMin once they reach the lockout threshold.
+	LoginWindowMin      int
+	LoginBackoffAfter   int
+	LoginLockoutAfter   int
+	LoginIPLockoutAfter int
+	LoginLockoutMin     int
+}
+
+// PaymentConfig selects the payment provider. The fake provider marks every
+// checkout paid, so it is only for development.
+type PaymentConfig struct {
+	FakeProvider bool
+}
+
+type NewsletterConfig struct {
+	Secret    string // signs unsubscribe links
+	PublicURL string // base URL of the notification service's HTTP endpoints
 }
 
 type Config struct {
@@ -57,6 +107,10 @@ type Config struct {
 	Redis               RedisConfig
 	JWT                 JWTConfig
 	RabbitMQ            RabbitMQConfig
+	Blog                BlogConfig
+	Newsletter          NewsletterConfig
+	Account             AccountConfig
+	Payment             PaymentConfig
 	GRPCTimeoutSec      int
 	GRPCRetryCount      int
 	LogLevel            string
@@ -120,6 +174,48 @@ func Load() *Config {
 			ExchangeType:          getEnv("RABBITMQ_EXCHANGE_TYPE", ""),
 			BlogCreatedRoutingKey: getEnv("RABBITMQ_BLOG_CREATED_ROUTING_KEY", ""),
 			NotificationQueue:     getEnv("RABBITMQ_NOTIFICATION_QUEUE", ""),
+			NewsletterQueue:       getEnv("RABBITMQ_NEWSLETTER_QUEUE", "newsletter.queue"),
+			FederationQueue:       getEnv("RABBITMQ_FEDERATION_QUEUE", "federation.queue"),
+			WebmentionQueue:       getEnv("RABBITMQ_WEBMENTION_QUEUE", "webmention.queue"),
+		},
+
+		Blog: BlogConfig{
+			DefaultLocale:  getEnv("BLOG_DEFAULT_LOCALE", "en"),
+			LocaleFallback: getEnvAsList("BLOG_LOCALE_FALLBACK", []string{"en"}),
+			RequireReview:  getEnvAsBool("BLOG_REQUIRE_REVIEW", false),
+			EditLockTTLSec: getEnvAsInt("BLOG_EDIT_LOCK_TTL_SEC", 60),
+			AutosaveSec:    getEnvAsInt("BLOG_AUTOSAVE_INTERVAL_SEC", 5),
+			PublicURL:      getEnv("BLOG_PUBLIC_URL", "http://localhost:8003"),
+			SiteName:       getEnv("BLOG_SITE_NAME", "Blog"),
+			OEmbedURL:      getEnv("BLOG_OEMBED_URL", "http://localhost:8003/oembed"),
+			WebmentionURL:  getEnv("BLOG_WEBMENTION_URL", "http://localhost:8003/webmention"),
+			MediaURL:       getEnv("BLOG_MEDIA_URL", "http://localhost:8003/media"),
+
+			AllowPrivateFetch: getEnvAsBool("BLOG_ALLOW_PRIVATE_FETCH", false),
+		},
+
+		Newsletter: NewsletterConfig{
+			Secret:    getEnv("NEWSLETTER_SECRET", ""),
+			PublicURL: getEnv("NEWSLETTER_PUBLIC_URL", "http://localhost:8004"),
+		},
+
+		Account: AccountConfig{
+			Secret:            getEnv("ACCOUNT_SECRET", ""),
+			PublicURL:         getEnv("ACCOUNT_PUBLIC_URL", "http://localhost:8001"),
+			ResetTTLMin:       getEnvAsInt("ACCOUNT_RESET_TTL_MIN", 60),
+			VerifyTTLHours:    getEnvAsInt("ACCOUNT_VERIFY_TTL_HOURS", 48),
+			ResendIntervalSec: getEnvAsInt("ACCOUNT_RESEND_INTERVAL_SEC", 60),
+			TOTPIssuer:        getEnv("ACCOUNT_TOTP_ISSUER", "Blog-Service"),
+
+			LoginWindowMin:      getEnvAsInt("LOGIN_FAILURE_WINDOW_MIN", 15),
+			LoginBackoffAfter:   getEnvAsInt("LOGIN_BACKOFF_AFTER", 3),
+			LoginLockoutAfter:   getEnvAsInt("LOGIN_LOCKOUT_AFTER", 10),
+			LoginIPLockoutAfter: getEnvAsInt("LOGIN_IP_LOCKOUT_AFTER", 50),
+			LoginLockoutMin:     getEnvAsInt("LOGIN_LOCKOUT_MIN", 15),
+		},
+
+		Payment: PaymentConfig{
+			FakeProvider: getEnvAsBool("PAYMENT_FAKE_PROVIDER", false),
 		},
 
 		GRPCTimeoutSec: getEnvAsInt("GRPC_TIMEOUT_SEC", 3),
@@ -146,3 +242,27 @@ func getEnvAsInt(key string, fallback int) int {
 	}
 	return fallback
 }
+
+func getEnvAsBool(key string, fallback bool) bool {
+	if val := os.Getenv(key); val != "" {
+		if b, err := strconv.ParseBool(val); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+func getEnvAsList(key string, fallback []string) []string {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+
+	var out []string
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
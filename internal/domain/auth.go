@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used, please log in again")
+	ErrInvalidResetToken   = errors.New("invalid or expired password reset token")
+	// ErrInvalidCredentials covers unknown emails too, so that logins don't
+	// reveal which accounts exist.
+	ErrInvalidCredentials = errors.New("invalid email or password")
+)
+
+// TokenPair is what a login or refresh hands out: a short-lived access token
+// and the refresh token to get the next pair with. A login that still needs
+// a second factor hands out only an MFAToken.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	MFAToken     string
+}
+
+// LoginThrottledError rejects a login attempted while earlier failures
+// hold the account or the client address back.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return "too many failed logins, try again later"
+}
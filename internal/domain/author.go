@@ -1,5 +1,12 @@
 package domain
 
+import "errors"
+
+var (
+	ErrAlreadyAuthor  = errors.New("already author")
+	ErrAuthorNotFound = errors.New("author not found")
+)
+
 type Author struct {
 	ID     uint
 	UserID uint
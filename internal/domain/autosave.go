@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var ErrAutosaveThrottled = errors.New("autosaving too often, try again shortly")
+
+// Autosave is the latest unsaved state of an author's editor for a post, kept
+// apart from the post itself. PostID is 0 for a post not created yet.
+type Autosave struct {
+	UserID  uint      `json:"user_id"`
+	PostID  uint      `json:"post_id"`
+	Title   string    `json:"title"`
+	Content string    `json:"content"`
+	Hash    string    `json:"hash"`
+	SavedAt time.Time `json:"saved_at"`
+}
+
+// ContentHash identifies a title and content pair, so that identical
+// autosaves can be skipped.
+func ContentHash(title, content string) string {
+	sum := sha256.Sum256([]byte(title + "\x00" + content))
+	return hex.EncodeToString(sum[:])
+}
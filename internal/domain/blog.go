@@ -1,23 +1,70 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	PostStatusDraft     = "DRAFT"
+	PostStatusPublished = "PUBLISHED"
+)
+
+// Visibility controls who can read a published post.
+const (
+	VisibilityPublic   = "PUBLIC"   // everyone, listed everywhere
+	VisibilityUnlisted = "UNLISTED" // anyone who knows the slug, never listed
+	VisibilityMembers  = "MEMBERS"  // any logged-in user
+	VisibilityPrivate  = "PRIVATE"  // only the post's author
+)
+
+// AnyVersion as the expected version of an update matches whatever version
+// the post has, like If-Match: *.
+const AnyVersion = ^uint(0)
+
+var (
+	ErrPostNotFound    = errors.New("post not found")
+	ErrVersionRequired = errors.New("expected version is required")
+	ErrVersionConflict = errors.New("post was modified by someone else")
+)
 
 type BlogPost struct {
-	ID        uint
-	AuthorID  uint
-	Title     string
-	Content   string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID            uint
+	PublicationID uint
+	AuthorID      uint
+	Slug          string
+	Locale        string
+	Title         string
+	Content       string
+	Tags          []string
+	Categories    []string
+	Status        string
+	Visibility    string
+	// RequiredTier paywalls the content after PaywallMarker for readers
+	// below that membership tier. Empty for free posts.
+	RequiredTier string
+	SEO          PostSEO
+	PublishedAt  *time.Time
+	Version      uint // incremented on every update, for optimistic locking
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 func NewBlogPost(authorId uint, title, content string) *BlogPost {
+	now := time.Now()
 	return &BlogPost{
-		AuthorID:  authorId,
-		Title:     title,
-		Content:   content,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		AuthorID:    authorId,
+		Slug:        Slugify(title),
+		Title:       title,
+		Content:     content,
+		Status:      PostStatusPublished,
+		Visibility:  VisibilityPublic,
+		PublishedAt: &now,
+		Version:     1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
 	}
 }
 
@@ -26,3 +73,161 @@ func (b *BlogPost) Update(title, content string) {
 	b.Content = content
 	b.UpdatedAt = time.Now()
 }
+
+func (b *BlogPost) IsDraft() bool {
+	return b.Status == PostStatusDraft
+}
+
+func ValidVisibility(v string) bool {
+	switch v {
+	case VisibilityPublic, VisibilityUnlisted, VisibilityMembers, VisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// Viewer identifies who is reading. The zero value is an anonymous reader.
+type Viewer struct {
+	UserID   uint
+	Roles    []string
+	Tier     string // membership tier from the JWT, empty for anonymous readers
+	AuthorID uint   // zero unless the viewer is an author
+}
+
+func (v Viewer) LoggedIn() bool {
+	return v.UserID != 0
+}
+
+// Can reports whether the viewer's roles grant perm.
+func (v Viewer) Can(perm string) bool {
+	return HasPermission(v.Roles, perm)
+}
+
+// VisibleTo reports whether v may read the post. Authors always see their own
+// posts; everyone else only sees published posts their visibility allows.
+// Unlisted posts are only reachable when looked up by slug.
+func (b *BlogPost) VisibleTo(v Viewer, bySlug bool) bool {
+	if v.AuthorID != 0 && v.AuthorID == b.AuthorID {
+		return true
+	}
+	if b.Status != PostStatusPublished {
+		// Editors read everything that has been submitted for review.
+		return v.Can(PermPostReview) && b.Status != PostStatusDraft
+	}
+
+	switch b.Visibility {
+	case VisibilityUnlisted:
+		return bySlug
+	case VisibilityMembers:
+		return v.LoggedIn()
+	case VisibilityPrivate:
+		return false
+	default:
+		return true
+	}
+}
+
+// ListedVisibilities returns the visibilities v may see in listings.
+func (v Viewer) ListedVisibilities() []string {
+	if v.LoggedIn() {
+		return []string{VisibilityPublic, VisibilityMembers}
+	}
+	return []string{VisibilityPublic}
+}
+
+// PostChanges holds the editable fields of a post update.
+type PostChanges struct {
+	Title      string
+	Content    string
+	Tags       []string // nil leaves the tags unchanged
+	Visibility string   // empty leaves the visibility unchanged
+	SEO        *PostSEO // nil leaves the SEO settings unchanged
+	// RequiredTier is nil to leave the paywall unchanged, or points to the
+	// tier required to read past the marker ("" for a free post).
+	RequiredTier *string
+}
+
+// PostTranslation holds a post's title and content in a locale other than
+// the one it was written in.
+type PostTranslation struct {
+	ID        uint
+	BlogID    uint
+	Locale    string
+	Title     string
+	Content   string
+	UpdatedAt time.Time
+}
+
+// PostView is a post as served to a reader, rendered in a single locale.
+type PostView struct {
+	*BlogPost
+	AvailableLocales []string
+	Paywalled        bool // Content is only the preview
+}
+
+// ApplyPaywall cuts the content down to its preview when v is below the
+// post's required tier.
+func (v *PostView) ApplyPaywall(viewer Viewer) {
+	if !v.BlogPost.Paywalled(viewer) {
+		return
+	}
+	p := *v.BlogPost
+	p.Content = Preview(p.Content)
+	v.BlogPost = &p
+	v.Paywalled = true
+}
+
+// PostFilter narrows a post listing. Zero values match everything, except
+// PublicationID, which always applies.
+type PostFilter struct {
+	PublicationID uint
+	AuthorID      uint
+	Tag           string
+	Status        string
+	Visibilities  []string
+	// OwnerAuthorID's posts are included whatever their visibility.
+	OwnerAuthorID uint
+	Limit         int
+	Offset        int
+}
+
+// Translate returns a copy of the post with title, content and locale taken
+// from t.
+func (b *BlogPost) Translate(t *PostTranslation) *BlogPost {
+	p := *b
+	p.Locale = t.Locale
+	p.Title = t.Title
+	p.Content = t.Content
+	if t.UpdatedAt.After(p.UpdatedAt) {
+		p.UpdatedAt = t.UpdatedAt
+	}
+	return &p
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify turns a title into a lowercase, dash separated URL segment.
+func Slugify(s string) string {
+	s = nonSlugChars.ReplaceAllString(strings.ToLower(s), "-")
+	s = strings.Trim(s, "-")
+	if s == "" {
+		return "post"
+	}
+	return s
+}
+
+// NormalizeTags trims tags, drops empty and duplicate entries and strips
+// commas, which are used as the storage separator.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.TrimSpace(strings.ReplaceAll(t, ",", " "))
+		if t == "" || seen[strings.ToLower(t)] {
+			continue
+		}
+		seen[strings.ToLower(t)] = true
+		out = append(out, t)
+	}
+	return out
+}
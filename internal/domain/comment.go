@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+const (
+	CommentStatusPending  = "PENDING"
+	CommentStatusApproved = "APPROVED"
+	CommentStatusSpam     = "SPAM"
+)
+
+type Comment struct {
+	ID          uint
+	BlogID      uint
+	ParentID    uint
+	UserID      uint // zero for guest comments
+	AuthorName  string
+	AuthorEmail string
+	AuthorURL   string
+	Content     string
+	Status      string
+	CreatedAt   time.Time
+}
+
+func NewComment(blogID, userID uint, content string) *Comment {
+	return &Comment{
+		BlogID:    blogID,
+		UserID:    userID,
+		Content:   content,
+		Status:    CommentStatusPending,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Post event types. They double as RabbitMQ routing keys.
+const (
+	EventPostCreated = "blog.created"
+	EventPostUpdated = "blog.updated"
+	EventPostDeleted = "blog.deleted"
+)
+
+// PostEvent is published on every change to a post. ID is a monotonically
+// increasing sequence number assigned when the event is stored, which
+// subscribers use to resume after a disconnect.
+type PostEvent struct {
+	ID            uint64    `json:"id"`
+	Type          string    `json:"type"`
+	PublicationID uint      `json:"publication_id"`
+	PostID        uint      `json:"post_id"`
+	AuthorID      uint      `json:"author_id"`
+	AuthorUserID  uint      `json:"author_user_id"`
+	Tags          []string  `json:"tags"`
+	Post          *BlogPost `json:"post,omitempty"` // nil for deletions
+	OccurredAt    time.Time `json:"occurred_at"`
+
+	// The post's last status and visibility, which decide who may see a
+	// deletion.
+	Status     string `json:"status"`
+	Visibility string `json:"visibility"`
+
+	// Set on review events.
+	ActorUserID uint            `json:"actor_user_id,omitempty"`
+	Note        string          `json:"note,omitempty"`
+	Comments    []ReviewComment `json:"comments,omitempty"`
+}
+
+func NewPostEvent(eventType string, post *BlogPost) *PostEvent {
+	e := &PostEvent{
+		Type:          eventType,
+		PublicationID: post.PublicationID,
+		PostID:        post.ID,
+		AuthorID:      post.AuthorID,
+		Tags:          post.Tags,
+		OccurredAt:    time.Now(),
+		Status:        post.Status,
+		Visibility:    post.Visibility,
+	}
+	if eventType != EventPostDeleted {
+		e.Post = post
+	}
+	return e
+}
+
+// VisibleTo reports whether v may see the event, judged by the post or, for
+// a deletion, by what the post last was. Deletions logged without that state
+// are shown to the author only.
+func (e *PostEvent) VisibleTo(v Viewer) bool {
+	if e.Post != nil {
+		return e.Post.VisibleTo(v, false)
+	}
+	if e.Status == "" {
+		return v.AuthorID != 0 && v.AuthorID == e.AuthorID
+	}
+	last := BlogPost{AuthorID: e.AuthorID, Status: e.Status, Visibility: e.Visibility}
+	return last.VisibleTo(v, false)
+}
+
+// PostEventFilter selects the events a subscriber receives. Zero values
+// match everything, except for the publication.
+type PostEventFilter struct {
+	PublicationID uint // always applied: 0 is the default publication
+	AuthorID      uint
+	Tag           string
+}
+
+func (f PostEventFilter) Matches(e *PostEvent) bool {
+	if e.PublicationID != f.PublicationID {
+		return false
+	}
+	if f.AuthorID != 0 && e.AuthorID != f.AuthorID {
+		return false
+	}
+	if f.Tag == "" {
+		return true
+	}
+	for _, t := range e.Tags {
+		if strings.EqualFold(t, f.Tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrActorNotFound    = errors.New("actor not found")
+	ErrUnauthorizedPost = errors.New("activity is not signed by its actor")
+)
+
+// ActorKey is the key pair an author's ActivityPub actor signs deliveries
+// with. It is created the first time the actor is needed.
+type ActorKey struct {
+	AuthorID      uint
+	PublicKeyPEM  string
+	PrivateKeyPEM string
+}
+
+// Follower is a remote ActivityPub actor following an author. Inbox is where
+// activities are delivered: the actor's shared inbox if it has one.
+type Follower struct {
+	ID        uint
+	AuthorID  uint
+	ActorID   string
+	Inbox     string
+	CreatedAt time.Time
+}
+
+// Delivery is an activity waiting to be POSTed to a remote inbox. Failed
+// deliveries are retried with backoff until maxDeliveryAttempts.
+type Delivery struct {
+	ID            uint
+	Key           string // deduplicates deliveries of one activity to one inbox
+	AuthorID      uint
+	Inbox         string
+	Payload       string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
+
+// maxDeliveryAttempts bounds the attempts at a delivery to a remote server.
+const maxDeliveryAttempts = 8
+
+// retryDelay is the wait after the given number of failed attempts,
+// doubling each time from one minute.
+func retryDelay(attempts int) time.Duration {
+	return time.Minute << (attempts - 1)
+}
+
+// Failed records a failed attempt and schedules the next one. It returns
+// false once the delivery should be given up.
+func (d *Delivery) Failed(err error, now time.Time) bool {
+	d.Attempts++
+	d.LastError = err.Error()
+	d.NextAttemptAt = now.Add(retryDelay(d.Attempts))
+	return d.Attempts < maxDeliveryAttempts
+}
@@ -0,0 +1,9 @@
+package domain
+
+// ImportRef identifies the object of another site that a local one was
+// imported from, so that rerunning an import skips it.
+type ImportRef struct {
+	Source     string
+	Kind       string
+	ExternalID string
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrPostLocked  = errors.New("post is being edited by another user")
+	ErrLockNotHeld = errors.New("edit lock is not held by this user")
+)
+
+// EditLock is a short-lived lease on editing a post. The holder renews it by
+// heartbeat; it lapses at ExpiresAt otherwise.
+type EditLock struct {
+	PostID    uint      `json:"post_id"`
+	UserID    uint      `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxMediaSize caps the size of an uploaded file.
+	MaxMediaSize = 10 << 20
+	// MediaQuota caps the total size of the files a user uploaded.
+	MediaQuota = 500 << 20
+)
+
+var (
+	ErrMediaNotFound      = errors.New("media not found")
+	ErrUnsupportedMedia   = errors.New("only image, audio and video files can be uploaded")
+	ErrMediaQuotaExceeded = errors.New("upload quota exceeded")
+)
+
+// Media is a file a user uploaded, such as a photo for a post.
+type Media struct {
+	ID          uint
+	UserID      uint
+	Name        string
+	ContentType string
+	Data        []byte
+	CreatedAt   time.Time
+}
+
+// NewMedia checks an upload and returns it as media of userID. The content
+// type is sniffed from the data rather than trusted from the client.
+func NewMedia(userID uint, name string, data []byte) (*Media, error) {
+	contentType := http.DetectContentType(data)
+	kind, _, _ := strings.Cut(contentType, "/")
+	if kind != "image" && kind != "audio" && kind != "video" {
+		return nil, ErrUnsupportedMedia
+	}
+	return &Media{
+		UserID:      userID,
+		Name:        name,
+		ContentType: contentType,
+		Data:        data,
+		CreatedAt:   time.Now(),
+	}, nil
+}
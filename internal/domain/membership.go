@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Membership tiers, from lowest to highest. Every reader has at least
+// TierFree; higher tiers come from entitlements.
+const (
+	TierFree      = "FREE"
+	TierSupporter = "SUPPORTER"
+	TierPremium   = "PREMIUM"
+)
+
+// PaywallMarker separates the free preview of a paywalled post from the
+// rest of its content.
+const PaywallMarker = "<!--paywall-->"
+
+var (
+	ErrInvalidTier = errors.New("invalid membership tier")
+	// ErrEntitlementExists means an entitlement was already issued for the
+	// same source and reference, e.g. the same payment.
+	ErrEntitlementExists = errors.New("entitlement already issued")
+)
+
+var tierRanks = map[string]int{TierFree: 0, TierSupporter: 1, TierPremium: 2}
+
+func ValidTier(t string) bool {
+	_, ok := tierRanks[t]
+	return ok
+}
+
+// TierAtLeast reports whether tier grants access to content requiring
+// required. Unknown tiers count as TierFree.
+func TierAtLeast(tier, required string) bool {
+	return tierRanks[tier] >= tierRanks[required]
+}
+
+// Entitlement grants a user a membership tier, until ExpiresAt if set.
+// Source records who issued it: an admin or a payment.
+type Entitlement struct {
+	ID        uint       `json:"id"`
+	UserID    uint       `json:"user_id"`
+	Tier      string     `json:"tier"`
+	Source    string     `json:"source"`
+	Reference string     `json:"reference,omitempty"` // e.g. the payment ID
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+const (
+	EntitlementSourceAdmin   = "ADMIN"
+	EntitlementSourcePayment = "PAYMENT"
+)
+
+func NewEntitlement(userID uint, tier, source string, expiresAt *time.Time) (*Entitlement, error) {
+	if !ValidTier(tier) || tier == TierFree {
+		return nil, ErrInvalidTier
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, errors.New("expiry must be in the future")
+	}
+	return &Entitlement{
+		UserID:    userID,
+		Tier:      tier,
+		Source:    source,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (e *Entitlement) Active(now time.Time) bool {
+	return e.ExpiresAt == nil || e.ExpiresAt.After(now)
+}
+
+// HighestTier returns the best tier granted by the active entitlements.
+func HighestTier(entitlements []*Entitlement) string {
+	tier := TierFree
+	now := time.Now()
+	for _, e := range entitlements {
+		if e.Active(now) && TierAtLeast(e.Tier, tier) {
+			tier = e.Tier
+		}
+	}
+	return tier
+}
+
+// Paywalled reports whether v only gets the preview of the post. Its author
+// and editors always read the full text.
+func (b *BlogPost) Paywalled(v Viewer) bool {
+	if b.RequiredTier == "" || TierAtLeast(v.Tier, b.RequiredTier) {
+		return false
+	}
+	if v.AuthorID != 0 && v.AuthorID == b.AuthorID {
+		return false
+	}
+	return !v.Can(PermPostReview)
+}
+
+// previewLength is the length of the preview of a paywalled post whose
+// author left out the paywall marker.
+const previewLength = 300
+
+// Preview returns the part of the content before the paywall marker. Without
+// a marker only a short plain-text excerpt is free, so forgetting the marker
+// doesn't give the post away.
+func Preview(content string) string {
+	if i := strings.Index(content, PaywallMarker); i >= 0 {
+		return strings.TrimSpace(content[:i])
+	}
+	return Excerpt(content, previewLength)
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "errors"
+
+// RecoveryCodeCount is how many recovery codes enabling 2FA issues.
+const RecoveryCodeCount = 10
+
+var (
+	ErrMFANotEnrolled      = errors.New("two-factor authentication is not set up")
+	ErrMFAAlreadyEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrInvalidMFACode      = errors.New("invalid authentication code")
+	ErrInvalidMFAChallenge = errors.New("invalid or expired login challenge, please log in again")
+)
+
+// TOTPEnrollment is a user's authenticator. It is pending until confirmed
+// with a first code.
+type TOTPEnrollment struct {
+	UserID  uint
+	Secret  string
+	Enabled bool
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidEmail         = errors.New("invalid email address")
+	ErrInvalidConfirmToken  = errors.New("invalid confirmation link")
+	ErrInvalidUnsubscribe   = errors.New("invalid unsubscribe link")
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+	ErrSubscribeThrottled   = errors.New("too many subscription requests, try again later")
+)
+
+// ConfirmTokenTTL is how long a confirmation link works.
+const ConfirmTokenTTL = 48 * time.Hour
+
+// Subscriber is a newsletter subscription by email, without a user account.
+// AuthorID 0 subscribes to all posts, otherwise to one author's posts.
+// Subscriptions only receive mail once confirmed through the emailed link.
+type Subscriber struct {
+	ID             uint
+	Email          string
+	AuthorID       uint
+	Confirmed      bool
+	TokenHash      string     // sha256 of the pending confirmation token
+	TokenExpiresAt *time.Time // when the pending confirmation token stops working
+	CreatedAt      time.Time
+	ConfirmedAt    *time.Time
+}
+
+func NewSubscriber(email string, authorID uint) (*Subscriber, error) {
+	addr, err := NormalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	return &Subscriber{
+		Email:     addr,
+		AuthorID:  authorID,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// SetToken makes tokenHash the pending confirmation token.
+func (s *Subscriber) SetToken(tokenHash string) {
+	expires := time.Now().Add(ConfirmTokenTTL)
+	s.TokenHash = tokenHash
+	s.TokenExpiresAt = &expires
+}
+
+// TokenPending reports whether a confirmation link was sent that still works.
+func (s *Subscriber) TokenPending() bool {
+	return s.TokenHash != "" && s.TokenExpiresAt != nil && time.Now().Before(*s.TokenExpiresAt)
+}
+
+func (s *Subscriber) Confirm() {
+	now := time.Now()
+	s.Confirmed = true
+	s.ConfirmedAt = &now
+	s.TokenHash = ""
+	s.TokenExpiresAt = nil
+}
+
+// NormalizeEmail validates a bare email address and lowercases it.
+func NormalizeEmail(email string) (string, error) {
+	addr, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil || addr.Name != "" {
+		return "", ErrInvalidEmail
+	}
+	return strings.ToLower(addr.Address), nil
+}
+
+// OutboundEmail is a message waiting in the outbox for the mail sender.
+// Key makes enqueueing idempotent when an event is delivered twice.
+type OutboundEmail struct {
+	ID              uint
+	Key             string
+	To              string
+	Subject         string
+	Body            string
+	ListUnsubscribe string // one-click unsubscribe URL (RFC 8058), if any
+	Sent            bool
+	CreatedAt       time.Time
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+const (
+	maxDisplayNameLength = 100
+	maxBioLength         = 1000
+)
+
+var (
+	ErrUserNotFound   = errors.New("user not found")
+	ErrInvalidProfile = errors.New("invalid profile")
+	ErrInvalidAvatar  = errors.New("avatar must be an image uploaded by the user")
+)
+
+// UserProfile is what a user shows about themselves.
+type UserProfile struct {
+	DisplayName   string
+	Bio           string
+	AvatarMediaID uint // uploaded image, 0 for none
+	Website       string
+}
+
+// ProfileChanges holds the fields of a profile update; nil fields are left
+// unchanged.
+type ProfileChanges struct {
+	DisplayName   *string
+	Bio           *string
+	AvatarMediaID *uint
+	Website       *string
+}
+
+// Apply validates the changes and applies them to p.
+func (c ProfileChanges) Apply(p *UserProfile) error {
+	next := *p
+	if c.DisplayName != nil {
+		next.DisplayName = strings.TrimSpace(*c.DisplayName)
+	}
+	if c.Bio != nil {
+		next.Bio = strings.TrimSpace(*c.Bio)
+	}
+	if c.AvatarMediaID != nil {
+		next.AvatarMediaID = *c.AvatarMediaID
+	}
+	if c.Website != nil {
+		next.Website = strings.TrimSpace(*c.Website)
+	}
+
+	if len([]rune(next.DisplayName)) > maxDisplayNameLength {
+		return errors.New("display name is too long")
+	}
+	if len([]rune(next.Bio)) > maxBioLength {
+		return errors.New("bio is too long")
+	}
+	if next.Website != "" {
+		u, err := url.Parse(next.Website)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("website must be an http(s) URL")
+		}
+	}
+	*p = next
+	return nil
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"errors"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultPublicationID is the publication of posts that belong to no team
+// publication: the deployment's own blog, configured through the
+// environment.
+const DefaultPublicationID = 0
+
+// Publication member roles. Owners manage the publication and its members,
+// editors review its posts and writers publish in it.
+const (
+	PublicationOwner  = "OWNER"
+	PublicationEditor = "EDITOR"
+	PublicationWriter = "WRITER"
+)
+
+var (
+	ErrPublicationNotFound = errors.New("publication not found")
+	ErrNotPublicationOwner = errors.New("only the publication's owners can do this")
+	ErrNotMember           = errors.New("user is not a member of this publication")
+	ErrInvalidDomain       = errors.New("invalid custom domain")
+	ErrDomainTaken         = errors.New("custom domain is already in use")
+	ErrDomainNotVerified   = errors.New("custom domain verification record not found")
+)
+
+// DomainVerificationPrefix names the DNS TXT record that proves control of
+// a custom domain: _blog-verification.{domain} must hold the publication's
+// DomainToken.
+const DomainVerificationPrefix = "_blog-verification."
+
+// Publication is a blog hosted on the deployment, owning its posts. It is
+// served on CustomDomain when set. A newly claimed domain stays in
+// PendingDomain until its DNS holds DomainToken.
+type Publication struct {
+	ID            uint                `json:"id"`
+	Slug          string              `json:"slug"`
+	Name          string              `json:"name"`
+	Description   string              `json:"description"`
+	CustomDomain  string              `json:"custom_domain,omitempty"`
+	PendingDomain string              `json:"pending_domain,omitempty"`
+	DomainToken   string              `json:"domain_token,omitempty"`
+	Settings      PublicationSettings `json:"settings"`
+	CreatedAt     time.Time           `json:"created_at"`
+}
+
+// PublicationSettings override the deployment's blog configuration.
+type PublicationSettings struct {
+	DefaultLocale string `json:"default_locale,omitempty"`
+	RequireReview bool   `json:"require_review"`
+}
+
+func NewPublication(name, slug, customDomain string) (*Publication, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("publication name cannot be empty")
+	}
+	if strings.TrimSpace(slug) == "" {
+		slug = name
+	}
+	return &Publication{
+		Slug:          Slugify(slug),
+		Name:          name,
+		PendingDomain: NormalizeHost(customDomain),
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+// PublicationMember gives a user a role in a publication.
+type PublicationMember struct {
+	PublicationID uint      `json:"publication_id"`
+	UserID        uint      `json:"user_id"`
+	Role          string    `json:"role"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func ValidPublicationRole(role string) bool {
+	switch role {
+	case PublicationOwner, PublicationEditor, PublicationWriter:
+		return true
+	}
+	return false
+}
+
+// NormalizeHost lowercases a Host header value and strips its port.
+func NormalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if i := strings.LastIndex(host, ":"); i >= 0 && !strings.HasSuffix(host, "]") {
+		host = host[:i]
+	}
+	return strings.TrimSuffix(host, ".")
+}
+
+var hostnameLabel = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// ValidDomain reports whether host is a normalized DNS name with at least
+// two labels. IP addresses are not.
+func ValidDomain(host string) bool {
+	if len(host) > 253 || net.ParseIP(host) != nil {
+		return false
+	}
+	labels := strings.Split(host, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, l := range labels {
+		if !hostnameLabel.MatchString(l) {
+			return false
+		}
+	}
+	return true
+}
+
+// DomainsOverlap reports whether a and b are the same host or one is a
+// subdomain of the other.
+func DomainsOverlap(a, b string) bool {
+	return a == b || strings.HasSuffix(a, "."+b) || strings.HasSuffix(b, "."+a)
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "errors"
+
+// Permissions are what roles grant; handlers and usecases check these
+// rather than role names.
+const (
+	PermPostCreate       = "post:create"       // write, edit and publish one's own posts
+	PermPostReview       = "post:review"       // read posts in review and approve, reject or comment on them
+	PermPostPublishAny   = "post:publish:any"  // publish anyone's approved posts
+	PermPostLockBreak    = "post:lock:break"   // break other users' edit locks
+	PermMembershipManage = "membership:manage" // grant and revoke membership entitlements
+	PermUserBan          = "user:ban"          // suspend and reinstate accounts
+	PermUserUnlock       = "user:unlock"       // lift failed-login lockouts
+	PermRoleAssign       = "role:assign"       // grant and withdraw staff roles
+)
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+// rolePermissions maps each role to the permissions it grants.
+var rolePermissions = map[string][]string{
+	RoleUser:      {},
+	RoleAuthor:    {PermPostCreate},
+	RoleEditor:    {PermPostReview, PermPostPublishAny, PermPostLockBreak},
+	RoleModerator: {PermUserBan, PermUserUnlock},
+	RoleAdmin: {
+		PermPostCreate,
+		PermPostReview,
+		PermPostPublishAny,
+		PermPostLockBreak,
+		PermMembershipManage,
+		PermUserBan,
+		PermUserUnlock,
+		PermRoleAssign,
+	},
+}
+
+// StaffRoles are the roles admins grant.
+var StaffRoles = []string{RoleAdmin, RoleEditor, RoleModerator}
+
+// ValidStaffRole reports whether role can be granted through the roles API.
+func ValidStaffRole(role string) bool {
+	for _, r := range StaffRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission reports whether any of the roles grants perm.
+func HasPermission(roles []string, perm string) bool {
+	for _, role := range roles {
+		for _, p := range rolePermissions[role] {
+			if p == perm {
+				return true
+			}
+		}
+	}
+	return false
+}
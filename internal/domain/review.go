@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Review workflow statuses, between PostStatusDraft and PostStatusPublished.
+const (
+	PostStatusInReview         = "IN_REVIEW"
+	PostStatusChangesRequested = "CHANGES_REQUESTED"
+	PostStatusApproved         = "APPROVED"
+	PostStatusRejected         = "REJECTED"
+)
+
+// Editor decisions on a post in review.
+const (
+	ReviewApprove        = "APPROVE"
+	ReviewRequestChanges = "REQUEST_CHANGES"
+	ReviewReject         = "REJECT"
+)
+
+// Review events, published with a PostEvent payload so the notification
+// service can tell the author.
+const (
+	EventReviewSubmitted        = "blog.review.submitted"
+	EventReviewApproved         = "blog.review.approved"
+	EventReviewChangesRequested = "blog.review.changes_requested"
+	EventReviewRejected         = "blog.review.rejected"
+	EventPostPublished          = "blog.published"
+)
+
+var (
+	ErrInvalidTransition = errors.New("post cannot move to that status from its current one")
+	ErrReviewRequired    = errors.New("post must be approved by an editor before publishing")
+	ErrNotEditor         = errors.New("user is not an editor")
+	ErrOwnPostReview     = errors.New("editors cannot review their own posts")
+)
+
+// ReviewComment is an editor's remark on part of a post under review.
+// Quote is the commented text and Offset its position in the content.
+type ReviewComment struct {
+	ID           uint      `json:"id"`
+	BlogID       uint      `json:"blog_id"`
+	EditorUserID uint      `json:"editor_user_id"`
+	Quote        string    `json:"quote,omitempty"`
+	Offset       int       `json:"offset,omitempty"`
+	Body         string    `json:"body"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// SubmitForReview hands a draft, or a post the editor sent back, to editors.
+func (b *BlogPost) SubmitForReview() error {
+	if b.Status != PostStatusDraft && b.Status != PostStatusChangesRequested {
+		return ErrInvalidTransition
+	}
+	b.Status = PostStatusInReview
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// Review applies an editor decision to a post in review and returns the
+// event type announcing it.
+func (b *BlogPost) Review(decision string) (string, error) {
+	if b.Status != PostStatusInReview {
+		return "", ErrInvalidTransition
+	}
+
+	var event string
+	switch decision {
+	case ReviewApprove:
+		b.Status, event = PostStatusApproved, EventReviewApproved
+	case ReviewRequestChanges:
+		b.Status, event = PostStatusChangesRequested, EventReviewChangesRequested
+	case ReviewReject:
+		b.Status, event = PostStatusRejected, EventReviewRejected
+	default:
+		return "", errors.New("unknown review decision")
+	}
+	b.UpdatedAt = time.Now()
+	return event, nil
+}
+
+// Publish makes the post public. With requireReview only approved posts may
+// be published; otherwise drafts may skip the review step, but a post that
+// entered review still has to be approved.
+func (b *BlogPost) Publish(requireReview bool) error {
+	switch b.Status {
+	case PostStatusApproved:
+	case PostStatusDraft:
+		if requireReview {
+			return ErrReviewRequired
+		}
+	case PostStatusPublished:
+		return ErrInvalidTransition
+	default:
+		return ErrReviewRequired
+	}
+
+	now := time.Now()
+	b.Status = PostStatusPublished
+	b.PublishedAt = &now
+	b.UpdatedAt = now
+	return nil
+}
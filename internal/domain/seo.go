@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// excerptLength is the maximum length of a derived meta description, the
+// size search engines usually display.
+const excerptLength = 160
+
+// PostSEO holds the search and social preview settings of a post. Empty
+// fields fall back to values derived from the post.
+type PostSEO struct {
+	MetaDescription string `json:"meta_description"`
+	CanonicalURL    string `json:"canonical_url"`
+	SocialImage     string `json:"social_image"`
+	NoIndex         bool   `json:"noindex"`
+}
+
+var (
+	markdownImage = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+)`)
+	htmlImage     = regexp.MustCompile(`(?i)<img[^>]+src\s*=\s*["']([^"']+)["']`)
+	htmlTag       = regexp.MustCompile(`<[^>]*>`)
+	markdownLink  = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	markdownMarks = regexp.MustCompile("(?m)^\\s{0,3}(#{1,6}|>|[-*+]|\\d+\\.)\\s+|[*_`~]")
+)
+
+// Description returns the post's meta description, or an excerpt of its
+// content when none was set.
+func (b *BlogPost) Description() string {
+	if b.SEO.MetaDescription != "" {
+		return b.SEO.MetaDescription
+	}
+	return Excerpt(b.freeContent(), excerptLength)
+}
+
+// Image returns the post's social image, or the first image in its content.
+func (b *BlogPost) Image() string {
+	if b.SEO.SocialImage != "" {
+		return b.SEO.SocialImage
+	}
+	return FirstImage(b.freeContent())
+}
+
+// freeContent is the part of the content anyone may read: the preview of a
+// paywalled post, or all of a free one up to a paywall marker.
+func (b *BlogPost) freeContent() string {
+	if b.RequiredTier == "" && !strings.Contains(b.Content, PaywallMarker) {
+		return b.Content
+	}
+	return Preview(b.Content)
+}
+
+// Indexable reports whether search engines may index the post. Only public
+// posts are, unless the author opted out.
+func (b *BlogPost) Indexable() bool {
+	return !b.SEO.NoIndex && b.Visibility == VisibilityPublic
+}
+
+// Excerpt turns markdown or HTML content into plain text cut at a word
+// boundary to at most n characters.
+func Excerpt(content string, n int) string {
+	text := markdownLink.ReplaceAllString(content, "$1")
+	text = htmlTag.ReplaceAllString(text, " ")
+	text = markdownMarks.ReplaceAllString(text, "")
+	text = strings.Join(strings.Fields(text), " ")
+
+	if utf8.RuneCountInString(text) <= n {
+		return text
+	}
+	runes := []rune(text)[:n]
+	if i := strings.LastIndex(string(runes), " "); i > 0 {
+		return string(runes)[:i] + "…"
+	}
+	return string(runes) + "…"
+}
+
+// FirstImage returns the URL of the first markdown or HTML image in content.
+func FirstImage(content string) string {
+	var first string
+	pos := -1
+	for _, re := range []*regexp.Regexp{markdownImage, htmlImage} {
+		if m := re.FindStringSubmatchIndex(content); m != nil && (pos < 0 || m[0] < pos) {
+			pos = m[0]
+			first = content[m[2]:m[3]]
+		}
+	}
+	return first
+}
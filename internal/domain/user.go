@@ -2,19 +2,43 @@ package domain
 
 import (
 	"errors"
+	"net/mail"
 	"strings"
 )
 
 const (
 	RoleUser   = "USER"
 	RoleAuthor = "AUTHOR"
+
+	// Staff roles, granted on top of USER or AUTHOR; see StaffRoles.
+	RoleEditor    = "EDITOR"
+	RoleModerator = "MODERATOR"
+	RoleAdmin     = "ADMIN"
+)
+
+var (
+	ErrUserBanned            = errors.New("account is suspended")
+	ErrEmailNotVerified      = errors.New("email address is not verified")
+	ErrEmailAlreadyVerified  = errors.New("email address is already verified")
+	ErrInvalidVerification   = errors.New("invalid or expired verification link")
+	ErrVerificationThrottled = errors.New("a verification email was sent recently, try again later")
+	ErrResetThrottled        = errors.New("too many password reset requests, try again later")
 )
 
 type User struct {
-	ID       uint
-	Email    string
-	Password string
-	Role     string
+	ID            uint
+	Email         string
+	Password      string
+	Role          string
+	EmailVerified bool
+	Banned        bool
+	Roles         []string // staff roles granted on top of Role
+	Profile       UserProfile
+}
+
+// AllRoles returns the user's role and staff roles.
+func (u *User) AllRoles() []string {
+	return append([]string{u.Role}, u.Roles...)
 }
 
 func NewUser(email, password string) (*User, error) {
@@ -24,6 +48,9 @@ func NewUser(email, password string) (*User, error) {
 	if email == "" || password == "" {
 		return nil, errors.New("email and password cannot be empty")
 	}
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return nil, errors.New("invalid email address")
+	}
 
 	u := &User{
 		Email:    email,
@@ -34,6 +61,14 @@ func NewUser(email, password string) (*User, error) {
 	return u, nil
 }
 
+// ValidatePassword checks a new password.
+func ValidatePassword(password string) error {
+	if strings.TrimSpace(password) == "" {
+		return errors.New("password cannot be empty")
+	}
+	return nil
+}
+
 func (u *User) PromoteToAuthor() {
 	u.Role = RoleAuthor
 }
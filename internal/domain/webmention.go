@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Incoming webmention statuses.
+const (
+	WebmentionPending  = "PENDING"  // waiting for the source to be verified
+	WebmentionVerified = "VERIFIED" // stored as a comment on the post
+	WebmentionInvalid  = "INVALID"  // the source does not link to the post
+)
+
+var (
+	ErrInvalidWebmention   = errors.New("source and target must be different http(s) URLs")
+	ErrWebmentionThrottled = errors.New("too many webmentions, try again later")
+)
+
+// OutgoingWebmention notifies Target that the post at Source links to it.
+type OutgoingWebmention struct {
+	ID            uint
+	PostID        uint
+	Source        string
+	Target        string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
+
+// Failed records a failed attempt and schedules the next one. It returns
+// false once the mention should be given up.
+func (w *OutgoingWebmention) Failed(err error, now time.Time) bool {
+	w.Attempts++
+	w.LastError = err.Error()
+	w.NextAttemptAt = now.Add(retryDelay(w.Attempts))
+	return w.Attempts < maxDeliveryAttempts
+}
+
+// IncomingWebmention is a notification that Source links to the post at
+// Target. Once verified it is shown as the comment CommentID, after
+// moderation like any other comment.
+type IncomingWebmention struct {
+	ID            uint
+	Source        string
+	Target        string
+	BlogID        uint
+	CommentID     uint
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
+
+// Failed records a failed verification and schedules the next one. It
+// returns false once the mention should be given up.
+func (w *IncomingWebmention) Failed(err error, now time.Time) bool {
+	w.Attempts++
+	w.LastError = err.Error()
+	w.NextAttemptAt = now.Add(retryDelay(w.Attempts))
+	return w.Attempts < maxDeliveryAttempts
+}
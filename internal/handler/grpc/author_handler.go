@@ -16,14 +16,18 @@ func NewAuthorHandler(u *usecase.AuthorUsecase) *AuthorHandler {
 	return &AuthorHandler{usecase: u}
 }
 
+// BecomeAuthor promotes the caller; the request's user_id is ignored.
 func (h *AuthorHandler) BecomeAuthor(ctx context.Context, req *authorpb.BecomeAuthorRequest) (*authorpb.AuthorResponse, error) {
-	userID := req.UserId
+	userID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	err := h.usecase.BecomeAuthor(uint(userID))
+	err = h.usecase.BecomeAuthor(userID, req.Name)
 	if err != nil {
 		return nil, err
 	}
 	return &authorpb.AuthorResponse{
-		UserId: userID,
+		UserId: uint64(userID),
 	}, nil
 }
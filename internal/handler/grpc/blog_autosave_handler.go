@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/proto/blogpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toAutosaveProto(a *domain.Autosave, saved bool) *blogpb.AutosaveResponse {
+	if a == nil {
+		return &blogpb.AutosaveResponse{}
+	}
+	return &blogpb.AutosaveResponse{
+		Saved:   saved,
+		Title:   a.Title,
+		Content: a.Content,
+		SavedAt: timestamppb.New(a.SavedAt),
+	}
+}
+
+func (h *Bloghandler) Autosave(ctx context.Context, req *blogpb.AutosaveRequest) (*blogpb.AutosaveResponse, error) {
+	viewer, err := editorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a, saved, err := h.blog(ctx).Autosave(viewer, uint(req.PostId), req.Title, req.Content)
+	if errors.Is(err, domain.ErrAutosaveThrottled) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if errors.Is(err, domain.ErrPermissionDenied) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return toAutosaveProto(a, saved), nil
+}
+
+func (h *Bloghandler) GetAutosave(ctx context.Context, req *blogpb.PostActionRequest) (*blogpb.AutosaveResponse, error) {
+	viewer, err := editorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := h.blog(ctx).RestorableAutosave(viewer, uint(req.PostId))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return toAutosaveProto(a, false), nil
+}
+
+func (h *Bloghandler) DiscardAutosave(ctx context.Context, req *blogpb.PostActionRequest) (*blogpb.AutosaveResponse, error) {
+	userID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.blog(ctx).DiscardAutosave(userID, uint(req.PostId)); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &blogpb.AutosaveResponse{}, nil
+}
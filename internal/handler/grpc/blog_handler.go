@@ -2,22 +2,36 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"strconv"
 
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/i18n"
 	"github.com/Hamiduzzaman96/Blog-Service/proto/blogpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Bloghandler struct {
 	blogpb.UnimplementedBlogServiceServer
-	usecase *usecase.BlogUsecase
+	usecase      *usecase.BlogUsecase
+	publications *usecase.PublicationUsecase
+	watcher      *usecase.PostWatcher
 }
 
-func NewBlogHandler(u *usecase.BlogUsecase) *Bloghandler {
-	return &Bloghandler{usecase: u}
+func NewBlogHandler(u *usecase.BlogUsecase, publications *usecase.PublicationUsecase, watcher *usecase.PostWatcher) *Bloghandler {
+	return &Bloghandler{usecase: u, publications: publications, watcher: watcher}
 }
 
 func (h *Bloghandler) CreatePost(ctx context.Context, req *blogpb.CreatePostRequest) (*blogpb.BlogResponse, error) {
-	err := h.usecase.CreatePost(uint(req.AuthorId), req.Title, req.Content)
+	userID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.blog(ctx).CreatePost(userID, req.Title, req.Content, req.Visibility)
 	if err != nil {
 		return nil, err
 	}
@@ -26,3 +40,193 @@ func (h *Bloghandler) CreatePost(ctx context.Context, req *blogpb.CreatePostRequ
 		Content: req.Content,
 	}, nil
 }
+
+// viewerFromContext returns the caller identified by the optional auth
+// interceptor, or an anonymous viewer.
+func viewerFromContext(ctx context.Context) domain.Viewer {
+	userID, _ := ctx.Value("user_id").(uint)
+	roles, _ := ctx.Value("user_roles").([]string)
+	tier, _ := ctx.Value("user_tier").(string)
+	return domain.Viewer{UserID: userID, Roles: roles, Tier: tier}
+}
+
+// callerFromContext returns the user authenticated by the interceptor. RPCs
+// that change posts act as this user, never as one named in the request.
+func callerFromContext(ctx context.Context) (uint, error) {
+	userID, _ := ctx.Value("user_id").(uint)
+	if userID == 0 {
+		return 0, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	return userID, nil
+}
+
+func toPostProto(v *domain.PostView) *blogpb.Post {
+	p := &blogpb.Post{
+		Id:               uint64(v.ID),
+		AuthorId:         uint64(v.AuthorID),
+		Slug:             v.Slug,
+		Locale:           v.Locale,
+		AvailableLocales: v.AvailableLocales,
+		Title:            v.Title,
+		Content:          v.Content,
+		Tags:             v.Tags,
+		Categories:       v.Categories,
+		Visibility:       v.Visibility,
+		Status:           v.Status,
+		RequiredTier:     v.RequiredTier,
+		Paywalled:        v.Paywalled,
+		Version:          uint64(v.Version),
+		CreatedAt:        timestamppb.New(v.CreatedAt),
+		UpdatedAt:        timestamppb.New(v.UpdatedAt),
+		Seo: &blogpb.PostSEO{
+			MetaDescription: v.SEO.MetaDescription,
+			CanonicalUrl:    v.SEO.CanonicalURL,
+			SocialImage:     v.SEO.SocialImage,
+			Noindex:         v.SEO.NoIndex,
+		},
+	}
+	if v.PublishedAt != nil {
+		p.PublishedAt = timestamppb.New(*v.PublishedAt)
+	}
+	return p
+}
+
+func (h *Bloghandler) GetPost(ctx context.Context, req *blogpb.GetPostRequest) (*blogpb.Post, error) {
+	if req.Id == 0 && req.Slug == "" {
+		return nil, status.Error(codes.InvalidArgument, "id or slug is required")
+	}
+
+	post, err := h.blog(ctx).GetPost(viewerFromContext(ctx), uint(req.Id), req.Slug, i18n.Preferred(req.Locale))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return toPostProto(post), nil
+}
+
+func (h *Bloghandler) UpdatePost(ctx context.Context, req *blogpb.UpdatePostRequest) (*blogpb.Post, error) {
+	viewer, err := editorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	if len(req.Tags) > 0 {
+		tags = req.Tags
+	}
+
+	var seo *domain.PostSEO
+	if req.Seo != nil {
+		seo = &domain.PostSEO{
+			MetaDescription: req.Seo.MetaDescription,
+			CanonicalURL:    req.Seo.CanonicalUrl,
+			SocialImage:     req.Seo.SocialImage,
+			NoIndex:         req.Seo.Noindex,
+		}
+	}
+
+	post, err := h.blog(ctx).UpdatePost(viewer, uint(req.PostId), uint(req.ExpectedVersion), domain.PostChanges{
+		Title:        req.Title,
+		Content:      req.Content,
+		Tags:         tags,
+		Visibility:   req.Visibility,
+		SEO:          seo,
+		RequiredTier: req.RequiredTier,
+	})
+	switch {
+	case errors.Is(err, domain.ErrVersionRequired):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrVersionConflict), errors.Is(err, domain.ErrPostLocked):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return toPostProto(&domain.PostView{BlogPost: post}), nil
+}
+
+func (h *Bloghandler) ListPosts(ctx context.Context, req *blogpb.ListPostsRequest) (*blogpb.ListPostsResponse, error) {
+	posts, err := h.blog(ctx).ListPosts(viewerFromContext(ctx), domain.PostFilter{
+		AuthorID: uint(req.AuthorId),
+		Tag:      req.Tag,
+		Limit:    int(req.Limit),
+		Offset:   int(req.Offset),
+	}, i18n.Preferred(req.Locale))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &blogpb.ListPostsResponse{}
+	for _, p := range posts {
+		resp.Posts = append(resp.Posts, toPostProto(p))
+	}
+	return resp, nil
+}
+
+func (h *Bloghandler) SetTranslation(ctx context.Context, req *blogpb.SetTranslationRequest) (*blogpb.BlogResponse, error) {
+	userID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.blog(ctx).SetTranslation(userID, uint(req.PostId), req.Locale, req.Title, req.Content)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &blogpb.BlogResponse{
+		Id:      req.PostId,
+		Title:   req.Title,
+		Content: req.Content,
+	}, nil
+}
+
+func (h *Bloghandler) DeletePost(ctx context.Context, req *blogpb.DeletePostRequest) (*blogpb.DeletePostResponse, error) {
+	userID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.blog(ctx).DeletePost(userID, uint(req.PostId), uint(req.ExpectedVersion))
+	switch {
+	case errors.Is(err, domain.ErrVersionConflict):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrPostNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &blogpb.DeletePostResponse{Success: true}, nil
+}
+
+func (h *Bloghandler) WatchPosts(req *blogpb.WatchPostsRequest, stream blogpb.BlogService_WatchPostsServer) error {
+	var resumeAfter uint64
+	if req.ResumeToken != "" {
+		var err error
+		if resumeAfter, err = strconv.ParseUint(req.ResumeToken, 10, 64); err != nil {
+			return status.Error(codes.InvalidArgument, "invalid resume token")
+		}
+	}
+
+	ctx := stream.Context()
+	filter := domain.PostEventFilter{
+		PublicationID: h.blog(ctx).PublicationID(),
+		AuthorID:      uint(req.AuthorId),
+		Tag:           req.Tag,
+	}
+
+	err := h.watcher.Watch(ctx, viewerFromContext(ctx), filter, resumeAfter, func(e *domain.PostEvent) error {
+		msg := &blogpb.PostEvent{
+			Type:        e.Type,
+			PostId:      uint64(e.PostID),
+			AuthorId:    uint64(e.AuthorID),
+			ResumeToken: strconv.FormatUint(e.ID, 10),
+			OccurredAt:  timestamppb.New(e.OccurredAt),
+		}
+		if e.Post != nil {
+			msg.Post = toPostProto(&domain.PostView{BlogPost: e.Post})
+		}
+		return stream.Send(msg)
+	})
+	if errors.Is(err, usecase.ErrWatchTooSlow) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return err
+}
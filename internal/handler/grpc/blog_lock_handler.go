@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/proto/blogpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toEditLockProto(l *domain.EditLock) *blogpb.EditLock {
+	if l == nil {
+		return nil
+	}
+	return &blogpb.EditLock{
+		PostId:    uint64(l.PostID),
+		UserId:    uint64(l.UserID),
+		ExpiresAt: timestamppb.New(l.ExpiresAt),
+	}
+}
+
+// lockError maps edit lock errors to gRPC status codes.
+func lockError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrPostLocked):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrLockNotHeld):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, domain.ErrPostNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrNotEditor):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+func (h *Bloghandler) AcquireEditLock(ctx context.Context, req *blogpb.PostActionRequest) (*blogpb.EditLockResponse, error) {
+	viewer, err := editorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := h.blog(ctx).AcquireEditLock(viewer, uint(req.PostId))
+	if errors.Is(err, domain.ErrPostLocked) {
+		return &blogpb.EditLockResponse{Lock: toEditLockProto(lock)}, nil
+	}
+	if err != nil {
+		return nil, lockError(err)
+	}
+	return &blogpb.EditLockResponse{Acquired: true, Lock: toEditLockProto(lock)}, nil
+}
+
+func (h *Bloghandler) RenewEditLock(ctx context.Context, req *blogpb.PostActionRequest) (*blogpb.EditLockResponse, error) {
+	viewer, err := editorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := h.blog(ctx).RenewEditLock(viewer, uint(req.PostId))
+	if err != nil {
+		return nil, lockError(err)
+	}
+	return &blogpb.EditLockResponse{Acquired: true, Lock: toEditLockProto(lock)}, nil
+}
+
+func (h *Bloghandler) ReleaseEditLock(ctx context.Context, req *blogpb.ReleaseEditLockRequest) (*blogpb.EditLockResponse, error) {
+	viewer, err := editorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.blog(ctx).ReleaseEditLock(viewer, uint(req.PostId), req.Force); err != nil {
+		return nil, lockError(err)
+	}
+	return &blogpb.EditLockResponse{}, nil
+}
+
+func (h *Bloghandler) GetEditLock(ctx context.Context, req *blogpb.GetEditLockRequest) (*blogpb.EditLockResponse, error) {
+	lock, err := h.blog(ctx).EditLock(viewerFromContext(ctx), uint(req.PostId))
+	if errors.Is(err, domain.ErrPostNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &blogpb.EditLockResponse{Lock: toEditLockProto(lock)}, nil
+}
@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// publicationFromMetadata resolves the publication a call is for: the
+// "x-publication-id" metadata if set, else the ":authority". A nil
+// publication is the default one.
+func (h *Bloghandler) publicationFromMetadata(ctx context.Context) (*domain.Publication, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var id uint64
+	if v := md.Get("x-publication-id"); len(v) > 0 && v[0] != "" {
+		var err error
+		if id, err = strconv.ParseUint(v[0], 10, 64); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid publication id")
+		}
+	}
+	var host string
+	if v := md.Get(":authority"); len(v) > 0 {
+		host = v[0]
+	}
+
+	pub, err := h.publications.Resolve(host, uint(id))
+	if errors.Is(err, domain.ErrPublicationNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return pub, err
+}
+
+// UnaryPublication resolves the publication of every blog call; see blog.
+func (h *Bloghandler) UnaryPublication() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		pub, err := h.publicationFromMetadata(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(context.WithValue(ctx, "publication", pub), req)
+	}
+}
+
+// StreamPublication is UnaryPublication for streaming RPCs.
+func (h *Bloghandler) StreamPublication() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		pub, err := h.publicationFromMetadata(ss.Context())
+		if err != nil {
+			return err
+		}
+		ctx := context.WithValue(ss.Context(), "publication", pub)
+		return handler(srv, &publicationStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type publicationStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *publicationStream) Context() context.Context {
+	return s.ctx
+}
+
+// publicationFromContext returns the publication resolved for the call.
+func publicationFromContext(ctx context.Context) *domain.Publication {
+	pub, _ := ctx.Value("publication").(*domain.Publication)
+	return pub
+}
+
+// blog returns the blog usecase scoped to the call's publication.
+func (h *Bloghandler) blog(ctx context.Context) *usecase.BlogUsecase {
+	return h.usecase.ForPublication(publicationFromContext(ctx))
+}
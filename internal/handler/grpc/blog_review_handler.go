@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/proto/blogpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// editorFromContext returns the logged-in caller. Whether they edit the
+// call's publication is checked by the usecase.
+func editorFromContext(ctx context.Context) (domain.Viewer, error) {
+	viewer := viewerFromContext(ctx)
+	if !viewer.LoggedIn() {
+		return viewer, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	return viewer, nil
+}
+
+// reviewError maps review workflow errors to gRPC status codes.
+func reviewError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrPostNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrNotEditor), errors.Is(err, domain.ErrNotMember),
+		errors.Is(err, domain.ErrOwnPostReview):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrInvalidTransition), errors.Is(err, domain.ErrReviewRequired),
+		errors.Is(err, domain.ErrVersionConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+func (h *Bloghandler) SubmitForReview(ctx context.Context, req *blogpb.PostActionRequest) (*blogpb.Post, error) {
+	userID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := h.blog(ctx).SubmitForReview(userID, uint(req.PostId))
+	if err != nil {
+		return nil, reviewError(err)
+	}
+	return toPostProto(&domain.PostView{BlogPost: post}), nil
+}
+
+// PublishPost publishes as the authenticated caller, whose roles may allow
+// publishing others' posts.
+func (h *Bloghandler) PublishPost(ctx context.Context, req *blogpb.PostActionRequest) (*blogpb.Post, error) {
+	viewer, err := editorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := h.blog(ctx).PublishPost(viewer, uint(req.PostId))
+	if err != nil {
+		return nil, reviewError(err)
+	}
+	return toPostProto(&domain.PostView{BlogPost: post}), nil
+}
+
+func (h *Bloghandler) ReviewPost(ctx context.Context, req *blogpb.ReviewPostRequest) (*blogpb.Post, error) {
+	editor, err := editorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]domain.ReviewComment, 0, len(req.Comments))
+	for _, c := range req.Comments {
+		comments = append(comments, domain.ReviewComment{Quote: c.Quote, Offset: int(c.Offset), Body: c.Body})
+	}
+
+	post, err := h.blog(ctx).ReviewPost(editor, uint(req.PostId), req.Decision, req.Note, comments)
+	if err != nil {
+		return nil, reviewError(err)
+	}
+	return toPostProto(&domain.PostView{BlogPost: post}), nil
+}
+
+func (h *Bloghandler) ListReviewQueue(ctx context.Context, req *blogpb.ListReviewQueueRequest) (*blogpb.ListPostsResponse, error) {
+	editor, err := editorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := h.blog(ctx).ReviewQueue(editor)
+	if err != nil {
+		return nil, reviewError(err)
+	}
+
+	resp := &blogpb.ListPostsResponse{}
+	for _, p := range posts {
+		resp.Posts = append(resp.Posts, toPostProto(&domain.PostView{BlogPost: p}))
+	}
+	return resp, nil
+}
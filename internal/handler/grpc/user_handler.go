@@ -2,18 +2,25 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"net"
 
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
 	"github.com/Hamiduzzaman96/Blog-Service/proto/userpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 type UserHandler struct {
 	userpb.UnimplementedUserServiceServer
-	usecase *usecase.UserUsecase
+	usecase  *usecase.UserUsecase
+	profiles *usecase.ProfileUsecase
 }
 
-func NewUserHandler(u *usecase.UserUsecase) *UserHandler {
-	return &UserHandler{usecase: u}
+func NewUserHandler(u *usecase.UserUsecase, profiles *usecase.ProfileUsecase) *UserHandler {
+	return &UserHandler{usecase: u, profiles: profiles}
 }
 
 func (h *UserHandler) Register(ctx context.Context, req *userpb.RegisterRequest) (*userpb.UserResponse, error) {
@@ -29,12 +36,194 @@ func (h *UserHandler) Register(ctx context.Context, req *userpb.RegisterRequest)
 }
 
 func (h *UserHandler) Login(ctx context.Context, req *userpb.LoginRequest) (*userpb.LoginResponse, error) {
-	token, err := h.usecase.Login(req.Email, req.Password)
+	tokens, err := h.usecase.Login(req.Email, req.Password, peerIP(ctx))
 	if err != nil {
-		return nil, err
+		return nil, loginError(err)
+	}
+
+	return &userpb.LoginResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		MfaToken:     tokens.MFAToken,
+	}, nil
+}
+
+func (h *UserHandler) LoginMFA(ctx context.Context, req *userpb.LoginMFARequest) (*userpb.LoginResponse, error) {
+	tokens, err := h.usecase.LoginMFA(req.MfaToken, req.Code, peerIP(ctx))
+	if err != nil {
+		return nil, loginError(err)
 	}
 
 	return &userpb.LoginResponse{
-		AccessToken: token,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	}, nil
 }
+
+// loginError maps a login failure to its gRPC status.
+func loginError(err error) error {
+	var throttled *domain.LoginThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, domain.ErrUserBanned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrInvalidCredentials),
+		errors.Is(err, domain.ErrInvalidMFACode),
+		errors.Is(err, domain.ErrInvalidMFAChallenge):
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// peerIP is the address of the calling client, empty if unknown.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func (h *UserHandler) Refresh(ctx context.Context, req *userpb.RefreshRequest) (*userpb.LoginResponse, error) {
+	tokens, err := h.usecase.Refresh(req.RefreshToken)
+	if errors.Is(err, domain.ErrInvalidRefreshToken) || errors.Is(err, domain.ErrRefreshTokenReused) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &userpb.LoginResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}, nil
+}
+
+func (h *UserHandler) Logout(ctx context.Context, req *userpb.LogoutRequest) (*userpb.LogoutResponse, error) {
+	userID, _ := ctx.Value("user_id").(uint)
+	session, _ := ctx.Value("session_id").(string)
+	if userID == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	var err error
+	if req.Everywhere {
+		err = h.usecase.LogoutEverywhere(userID)
+	} else {
+		err = h.usecase.Logout(userID, session)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &userpb.LogoutResponse{}, nil
+}
+
+// GetByID returns a user's email and roles to that user, and to the
+// admins and moderators who manage users.
+func (h *UserHandler) GetByID(ctx context.Context, req *userpb.GetByIDRequest) (*userpb.UserResponse, error) {
+	viewer := viewerFromContext(ctx)
+	if viewer.UserID == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if viewer.UserID != uint(req.UserId) && !viewer.Can(domain.PermRoleAssign) && !viewer.Can(domain.PermUserBan) {
+		return nil, status.Error(codes.PermissionDenied, domain.ErrPermissionDenied.Error())
+	}
+
+	user, err := h.profiles.GetByID(uint(req.UserId))
+	if err != nil {
+		return nil, profileError(err)
+	}
+	roles, err := h.usecase.Roles(user.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &userpb.UserResponse{
+		Id:    uint64(user.ID),
+		Email: user.Email,
+		Role:  user.Role,
+		Roles: roles,
+	}, nil
+}
+
+// SetRoles is guarded by the role:assign permission interceptor.
+func (h *UserHandler) SetRoles(ctx context.Context, req *userpb.SetRolesRequest) (*userpb.UserResponse, error) {
+	if err := h.usecase.SetRoles(viewerFromContext(ctx), uint(req.UserId), req.Roles); err != nil {
+		return nil, adminError(err)
+	}
+	return h.GetByID(ctx, &userpb.GetByIDRequest{UserId: req.UserId})
+}
+
+// BanUser is guarded by the user:ban permission interceptor.
+func (h *UserHandler) BanUser(ctx context.Context, req *userpb.BanUserRequest) (*userpb.BanUserResponse, error) {
+	if err := h.usecase.SetBanned(viewerFromContext(ctx), uint(req.UserId), req.Banned); err != nil {
+		return nil, adminError(err)
+	}
+	return &userpb.BanUserResponse{}, nil
+}
+
+// adminError maps errors of the admin RPCs to gRPC status codes.
+func adminError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+func (h *UserHandler) GetProfile(ctx context.Context, req *userpb.GetByIDRequest) (*userpb.Profile, error) {
+	user, err := h.profiles.GetByID(uint(req.UserId))
+	if err != nil {
+		return nil, profileError(err)
+	}
+	return h.toProfile(user), nil
+}
+
+func (h *UserHandler) UpdateProfile(ctx context.Context, req *userpb.UpdateProfileRequest) (*userpb.Profile, error) {
+	userID, _ := ctx.Value("user_id").(uint)
+	if userID == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	changes := domain.ProfileChanges{
+		DisplayName: req.DisplayName,
+		Bio:         req.Bio,
+		Website:     req.Website,
+	}
+	if req.AvatarMediaId != nil {
+		avatar := uint(*req.AvatarMediaId)
+		changes.AvatarMediaID = &avatar
+	}
+
+	user, err := h.profiles.UpdateProfile(userID, changes)
+	if err != nil {
+		return nil, profileError(err)
+	}
+	return h.toProfile(user), nil
+}
+
+func (h *UserHandler) toProfile(user *domain.User) *userpb.Profile {
+	return &userpb.Profile{
+		UserId:        uint64(user.ID),
+		DisplayName:   user.Profile.DisplayName,
+		Bio:           user.Profile.Bio,
+		AvatarMediaId: uint64(user.Profile.AvatarMediaID),
+		AvatarUrl:     h.profiles.AvatarURL(user.Profile),
+		Website:       user.Profile.Website,
+	}
+}
+
+// profileError maps profile errors to gRPC status codes.
+func profileError(err error) error {
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
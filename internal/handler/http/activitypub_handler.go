@@ -0,0 +1,119 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/activitypub"
+)
+
+// maxActivitySize caps the body of an activity POSTed to an inbox.
+const maxActivitySize = 1 << 20
+
+type FederationHandler struct {
+	usecase *usecase.FederationUsecase
+}
+
+func NewFederationHandler(u *usecase.FederationUsecase) *FederationHandler {
+	return &FederationHandler{usecase: u}
+}
+
+// federationStatus maps ActivityPub errors to HTTP status codes.
+func federationStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrActorNotFound), errors.Is(err, domain.ErrPostNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, activitypub.ErrInvalidSignature), errors.Is(err, domain.ErrUnauthorizedPost):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func writeActivityJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", activitypub.ContentType)
+	json.NewEncoder(w).Encode(v)
+}
+
+func queryID(r *http.Request) uint {
+	id, _ := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+	return uint(id)
+}
+
+// WebFinger answers /.well-known/webfinger?resource=acct:author{id}@host.
+func (h *FederationHandler) WebFinger(w http.ResponseWriter, r *http.Request) {
+	jrd, err := h.usecase.WebFinger(r.URL.Query().Get("resource"))
+	if err != nil {
+		http.Error(w, err.Error(), federationStatus(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/jrd+json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	json.NewEncoder(w).Encode(jrd)
+}
+
+// Actor serves the Person document of author ?id=.
+func (h *FederationHandler) Actor(w http.ResponseWriter, r *http.Request) {
+	actor, err := h.usecase.Actor(queryID(r))
+	if err != nil {
+		http.Error(w, err.Error(), federationStatus(err))
+		return
+	}
+	writeActivityJSON(w, actor)
+}
+
+// Inbox receives signed activities for author ?id=.
+func (h *FederationHandler) Inbox(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxActivitySize))
+	if err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.usecase.Inbox(queryID(r), r, body); err != nil {
+		http.Error(w, err.Error(), federationStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Outbox serves the outbox of author ?id=, paged with ?page=.
+func (h *FederationHandler) Outbox(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	outbox, err := h.usecase.Outbox(queryID(r), page)
+	if err != nil {
+		http.Error(w, err.Error(), federationStatus(err))
+		return
+	}
+	writeActivityJSON(w, outbox)
+}
+
+// Followers serves the followers collection of author ?id=.
+func (h *FederationHandler) Followers(w http.ResponseWriter, r *http.Request) {
+	followers, err := h.usecase.Followers(queryID(r))
+	if err != nil {
+		http.Error(w, err.Error(), federationStatus(err))
+		return
+	}
+	writeActivityJSON(w, followers)
+}
+
+// Article serves post ?id= as an ActivityPub Article.
+func (h *FederationHandler) Article(w http.ResponseWriter, r *http.Request) {
+	article, err := h.usecase.Article(queryID(r))
+	if err != nil {
+		http.Error(w, err.Error(), federationStatus(err))
+		return
+	}
+	article.Context = activitypub.Context
+	writeActivityJSON(w, article)
+}
@@ -22,7 +22,7 @@ func (h *AuthorHandler) BecomeAuthor(w http.ResponseWriter, r *http.Request) {
 	}
 	json.NewDecoder(r.Body).Decode(&req)
 
-	err := h.usecase.BecomeAuthor(userID)
+	err := h.usecase.BecomeAuthor(userID, req.Name)
 	if err != nil {
 		http.Error(w, err.Error(), 400)
 		return
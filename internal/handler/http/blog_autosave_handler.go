@@ -0,0 +1,67 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+)
+
+// Autosave serves the caller's editor autosaves for ?post_id= (0 or absent
+// for a new post): POST stores one, GET returns the version to offer for
+// restoring ("autosave": null when there is none) and DELETE discards it.
+func (h *BlogHandler) Autosave(w http.ResponseWriter, r *http.Request) {
+	viewer := viewerFromRequest(r)
+	postID, _ := strconv.ParseUint(r.URL.Query().Get("post_id"), 10, 64)
+
+	switch r.Method {
+	case http.MethodPost:
+		var req struct {
+			Title   string `json:"title"`
+			Content string `json:"content"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		a, saved, err := h.blog(r).Autosave(viewer, uint(postID), req.Title, req.Content)
+		if errors.Is(err, domain.ErrAutosaveThrottled) {
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"saved": saved, "saved_at": a.SavedAt})
+
+	case http.MethodGet:
+		a, err := h.blog(r).RestorableAutosave(viewer, uint(postID))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"autosave": a})
+
+	case http.MethodDelete:
+		if err := h.blog(r).DiscardAutosave(viewer.UserID, uint(postID)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
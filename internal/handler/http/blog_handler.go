@@ -2,37 +2,315 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/i18n"
 )
 
+// maxImportSize caps the size of an uploaded markdown archive.
+const maxImportSize = 32 << 20
+
 type BlogHandler struct {
-	usecase *usecase.BlogUsecase
+	usecase      *usecase.BlogUsecase
+	publications *usecase.PublicationUsecase
+}
+
+func NewBolgHandler(u *usecase.BlogUsecase, publications *usecase.PublicationUsecase) *BlogHandler {
+	return &BlogHandler{usecase: u, publications: publications}
+}
+
+// userFromContext returns the caller's user ID. Routes using it are
+// guarded by RequirePermission.
+func userFromContext(r *http.Request) uint {
+	userID, _ := r.Context().Value("user_id").(uint)
+	return userID
 }
 
-func NewBolgHandler(u *usecase.BlogUsecase) *BlogHandler {
-	return &BlogHandler{usecase: u}
+// viewerFromRequest returns the reader identified by OptionalAuth, or an
+// anonymous viewer.
+func viewerFromRequest(r *http.Request) domain.Viewer {
+	userID, _ := r.Context().Value("user_id").(uint)
+	roles, _ := r.Context().Value("user_roles").([]string)
+	tier, _ := r.Context().Value("user_tier").(string)
+	return domain.Viewer{UserID: userID, Roles: roles, Tier: tier}
 }
 
 func (h *BlogHandler) CreatePost(w http.ResponseWriter, r *http.Request) {
-	userIDVal := r.Context().Value("user_id")
-	roleVal := r.Context().Value("user_role")
+	userID := userFromContext(r)
 
-	if userIDVal == nil || roleVal == nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	var req struct {
+		Title      string `json:"title"`
+		Content    string `json:"content"`
+		Visibility string `json:"visibility"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
-	userID := userIDVal.(uint)
-	role := roleVal.(string)
+	err := h.blog(r).CreatePost(userID, req.Title, req.Content, req.Visibility)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+	w.WriteHeader(200)
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+type postResponse struct {
+	ID               uint           `json:"id"`
+	AuthorID         uint           `json:"author_id"`
+	Slug             string         `json:"slug"`
+	Locale           string         `json:"locale"`
+	AvailableLocales []string       `json:"available_locales"`
+	Title            string         `json:"title"`
+	Content          string         `json:"content"`
+	Tags             []string       `json:"tags"`
+	Categories       []string       `json:"categories"`
+	Status           string         `json:"status"`
+	Visibility       string         `json:"visibility"`
+	RequiredTier     string         `json:"required_tier,omitempty"`
+	Paywalled        bool           `json:"paywalled"`
+	SEO              domain.PostSEO `json:"seo"`
+	Version          uint           `json:"version"`
+	PublishedAt      *time.Time     `json:"published_at,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+func toPostResponse(v *domain.PostView) postResponse {
+	return postResponse{
+		ID:               v.ID,
+		AuthorID:         v.AuthorID,
+		Slug:             v.Slug,
+		Locale:           v.Locale,
+		AvailableLocales: v.AvailableLocales,
+		Title:            v.Title,
+		Content:          v.Content,
+		Tags:             v.Tags,
+		Categories:       v.Categories,
+		Status:           v.Status,
+		Visibility:       v.Visibility,
+		RequiredTier:     v.RequiredTier,
+		Paywalled:        v.Paywalled,
+		SEO:              v.SEO,
+		Version:          v.Version,
+		PublishedAt:      v.PublishedAt,
+		CreatedAt:        v.CreatedAt,
+		UpdatedAt:        v.UpdatedAt,
+	}
+}
+
+// preferredLocales reads the reader's locales from the "locale" query
+// parameter, falling back to the Accept-Language header.
+func preferredLocales(r *http.Request) []string {
+	if locale := r.URL.Query().Get("locale"); locale != "" {
+		return i18n.Preferred(locale)
+	}
+	return i18n.Preferred(r.Header.Get("Accept-Language"))
+}
+
+// GetPost serves a published post by ?id= or ?slug=.
+func (h *BlogHandler) GetPost(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	id, _ := strconv.ParseUint(q.Get("id"), 10, 64)
+	slug := q.Get("slug")
+	if id == 0 && slug == "" {
+		http.Error(w, "id or slug is required", http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.blog(r).GetPost(viewerFromRequest(r), uint(id), slug, preferredLocales(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
 
-	if role != "AUTHOR" {
-		http.Error(w, "user is not an author", http.StatusForbidden)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", post.Locale)
+	w.Header().Set("ETag", etag(post.Version))
+	w.Header().Add("Vary", "Accept-Language")
+	if post.VisibleTo(domain.Viewer{}, true) {
+		w.Header().Add("Link", fmt.Sprintf(`<%s>; rel="alternate"; type="application/json+oembed"`,
+			h.blog(r).OEmbedDiscoveryURL(post.Slug, "json")))
+		w.Header().Add("Link", fmt.Sprintf(`<%s>; rel="webmention"`, h.blog(r).WebmentionURL()))
+	}
+	json.NewEncoder(w).Encode(toPostResponse(post))
+}
+
+// ListPosts serves published posts, optionally filtered by ?author_id= and
+// ?tag=, paged with ?limit= and ?offset=.
+func (h *BlogHandler) ListPosts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	authorID, _ := strconv.ParseUint(q.Get("author_id"), 10, 64)
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	offset, _ := strconv.Atoi(q.Get("offset"))
+
+	posts, err := h.blog(r).ListPosts(viewerFromRequest(r), domain.PostFilter{
+		AuthorID: uint(authorID),
+		Tag:      strings.TrimSpace(q.Get("tag")),
+		Limit:    limit,
+		Offset:   offset,
+	}, preferredLocales(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]postResponse, 0, len(posts))
+	for _, p := range posts {
+		resp = append(resp, toPostResponse(p))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Vary", "Accept-Language")
+	json.NewEncoder(w).Encode(map[string]any{"posts": resp})
+}
+
+func etag(version uint) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// versionFromIfMatch reads the post version from an If-Match header holding
+// an ETag previously returned by GetPost. "*" matches any version, and a
+// weak validator (W/"3") is compared like the strong one, since proxies may
+// weaken ETags on the way.
+func versionFromIfMatch(header string) (uint, error) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, domain.ErrVersionRequired
+	}
+	if header == "*" {
+		return domain.AnyVersion, nil
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, errors.New("If-Match must carry the post's ETag")
+	}
+	v, err := strconv.ParseUint(tag[1:len(tag)-1], 10, 64)
+	if err != nil || v == 0 || uint(v) == domain.AnyVersion {
+		return 0, errors.New("If-Match must carry the post's ETag")
+	}
+	return uint(v), nil
+}
+
+// UpdatePost edits a post the caller may edit, identified by ?id=. The
+// If-Match header must carry the ETag the editor started from; stale writes
+// get 412 Precondition Failed.
+func (h *BlogHandler) UpdatePost(w http.ResponseWriter, r *http.Request) {
+	viewer := viewerFromRequest(r)
+	if r.Method != http.MethodPut && r.Method != http.MethodPatch {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	postID, _ := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+	if postID == 0 {
+		http.Error(w, "id is required", http.StatusBadRequest)
+		return
+	}
+
+	version, err := versionFromIfMatch(r.Header.Get("If-Match"))
+	if errors.Is(err, domain.ErrVersionRequired) {
+		http.Error(w, "If-Match header is required", http.StatusPreconditionRequired)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	var req struct {
+		Title      string          `json:"title"`
+		Content    string          `json:"content"`
+		Tags       []string        `json:"tags"`
+		Visibility string          `json:"visibility"`
+		SEO        *domain.PostSEO `json:"seo"`
+		// RequiredTier paywalls the post; "" makes it free again.
+		RequiredTier *string `json:"required_tier"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.blog(r).UpdatePost(viewer, uint(postID), version, domain.PostChanges{
+		Title:        req.Title,
+		Content:      req.Content,
+		Tags:         req.Tags,
+		Visibility:   req.Visibility,
+		SEO:          req.SEO,
+		RequiredTier: req.RequiredTier,
+	})
+	if errors.Is(err, domain.ErrVersionConflict) {
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+	if errors.Is(err, domain.ErrPostLocked) {
+		http.Error(w, err.Error(), http.StatusLocked)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("ETag", etag(post.Version))
+	json.NewEncoder(w).Encode(map[string]any{"status": "ok", "version": post.Version})
+}
+
+// DeletePost removes one of the caller's posts, identified by ?id=. An
+// optional If-Match header guards against deleting a post that changed.
+func (h *BlogHandler) DeletePost(w http.ResponseWriter, r *http.Request) {
+	userID := userFromContext(r)
+	if r.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	postID, _ := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+	if postID == 0 {
+		http.Error(w, "id is required", http.StatusBadRequest)
+		return
+	}
+
+	var version uint
+	if header := r.Header.Get("If-Match"); header != "" {
+		var err error
+		if version, err = versionFromIfMatch(header); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	err := h.blog(r).DeletePost(userID, uint(postID), version)
+	if errors.Is(err, domain.ErrVersionConflict) {
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// SetTranslation adds or replaces a translation of one of the caller's posts.
+func (h *BlogHandler) SetTranslation(w http.ResponseWriter, r *http.Request) {
+	userID := userFromContext(r)
+
+	var req struct {
+		PostID  uint   `json:"post_id"`
+		Locale  string `json:"locale"`
 		Title   string `json:"title"`
 		Content string `json:"content"`
 	}
@@ -41,11 +319,56 @@ func (h *BlogHandler) CreatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.usecase.CreatePost(userID, req.Title, req.Content)
-	if err != nil {
-		http.Error(w, err.Error(), 400)
+	if err := h.blog(r).SetTranslation(userID, req.PostID, req.Locale, req.Title, req.Content); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	w.WriteHeader(200)
 	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
 }
+
+// ImportMarkdown accepts a zip of front-matter markdown files, either as the
+// "file" field of a multipart form or as the raw request body.
+func (h *BlogHandler) ImportMarkdown(w http.ResponseWriter, r *http.Request) {
+	userID := userFromContext(r)
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	var src io.Reader = r.Body
+	if file, _, err := r.FormFile("file"); err == nil {
+		defer file.Close()
+		src = file
+	}
+
+	archive, err := io.ReadAll(src)
+	if err != nil {
+		http.Error(w, "archive too large or unreadable", http.StatusBadRequest)
+		return
+	}
+
+	count, err := h.blog(r).ImportMarkdown(userID, archive)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]int{"imported": count})
+}
+
+// ExportMarkdown streams the caller's posts as a zip of markdown files.
+func (h *BlogHandler) ExportMarkdown(w http.ResponseWriter, r *http.Request) {
+	userID := userFromContext(r)
+
+	archive, err := h.blog(r).ExportMarkdown(userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="posts.zip"`)
+	w.Write(archive)
+}
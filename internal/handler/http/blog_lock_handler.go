@@ -0,0 +1,121 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+)
+
+// lockStatus maps edit lock errors to HTTP status codes.
+func lockStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrPostLocked):
+		return http.StatusLocked
+	case errors.Is(err, domain.ErrLockNotHeld):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrPostNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrNotEditor):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// AcquireEditLock takes the edit lock on a post the caller may edit. While
+// another user holds it the response is 423 Locked with the current lock.
+func (h *BlogHandler) AcquireEditLock(w http.ResponseWriter, r *http.Request) {
+	viewer := viewerFromRequest(r)
+
+	var req struct {
+		PostID uint `json:"post_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	lock, err := h.blog(r).AcquireEditLock(viewer, req.PostID)
+	if errors.Is(err, domain.ErrPostLocked) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusLocked)
+		json.NewEncoder(w).Encode(map[string]any{"error": err.Error(), "lock": lock})
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), lockStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(lock)
+}
+
+// RenewEditLock is the heartbeat extending the caller's edit lock.
+func (h *BlogHandler) RenewEditLock(w http.ResponseWriter, r *http.Request) {
+	viewer := viewerFromRequest(r)
+
+	var req struct {
+		PostID uint `json:"post_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	lock, err := h.blog(r).RenewEditLock(viewer, req.PostID)
+	if err != nil {
+		http.Error(w, err.Error(), lockStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(lock)
+}
+
+// ReleaseEditLock gives up the caller's edit lock. Editors may send
+// "force": true to break a lock held by someone else.
+func (h *BlogHandler) ReleaseEditLock(w http.ResponseWriter, r *http.Request) {
+	viewer := viewerFromRequest(r)
+
+	var req struct {
+		PostID uint `json:"post_id"`
+		Force  bool `json:"force"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.blog(r).ReleaseEditLock(viewer, req.PostID, req.Force); err != nil {
+		http.Error(w, err.Error(), lockStatus(err))
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// GetEditLock reports who is editing ?post_id=, with "lock": null when
+// nobody is.
+func (h *BlogHandler) GetEditLock(w http.ResponseWriter, r *http.Request) {
+	postID, _ := strconv.ParseUint(r.URL.Query().Get("post_id"), 10, 64)
+	if postID == 0 {
+		http.Error(w, "post_id is required", http.StatusBadRequest)
+		return
+	}
+
+	lock, err := h.blog(r).EditLock(viewerFromRequest(r), uint(postID))
+	if errors.Is(err, domain.ErrPostNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"lock": lock})
+}
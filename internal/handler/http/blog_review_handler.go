@@ -0,0 +1,160 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+)
+
+// editorFromContext returns the logged-in caller, writing the error
+// response otherwise. Whether they edit the request's publication is
+// checked by the usecase.
+func editorFromContext(w http.ResponseWriter, r *http.Request) (domain.Viewer, bool) {
+	viewer := viewerFromRequest(r)
+	if !viewer.LoggedIn() {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return viewer, false
+	}
+	return viewer, true
+}
+
+// reviewStatus maps review workflow errors to HTTP status codes.
+func reviewStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrPostNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrNotEditor), errors.Is(err, domain.ErrNotMember),
+		errors.Is(err, domain.ErrOwnPostReview):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrInvalidTransition), errors.Is(err, domain.ErrReviewRequired),
+		errors.Is(err, domain.ErrVersionConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// SubmitForReview sends one of the caller's drafts to the editors.
+func (h *BlogHandler) SubmitForReview(w http.ResponseWriter, r *http.Request) {
+	userID := userFromContext(r)
+
+	var req struct {
+		PostID uint `json:"post_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.blog(r).SubmitForReview(userID, req.PostID); err != nil {
+		http.Error(w, err.Error(), reviewStatus(err))
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"status": domain.PostStatusInReview})
+}
+
+// ReviewPost records an editor's decision, optionally with inline comments.
+func (h *BlogHandler) ReviewPost(w http.ResponseWriter, r *http.Request) {
+	editor, ok := editorFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		PostID   uint   `json:"post_id"`
+		Decision string `json:"decision"`
+		Note     string `json:"note"`
+		Comments []struct {
+			Quote  string `json:"quote"`
+			Offset int    `json:"offset"`
+			Body   string `json:"body"`
+		} `json:"comments"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	comments := make([]domain.ReviewComment, 0, len(req.Comments))
+	for _, c := range req.Comments {
+		comments = append(comments, domain.ReviewComment{Quote: c.Quote, Offset: c.Offset, Body: c.Body})
+	}
+
+	if _, err := h.blog(r).ReviewPost(editor, req.PostID, req.Decision, req.Note, comments); err != nil {
+		http.Error(w, err.Error(), reviewStatus(err))
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// ReviewQueue lists the posts waiting for an editor.
+func (h *BlogHandler) ReviewQueue(w http.ResponseWriter, r *http.Request) {
+	editor, ok := editorFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	posts, err := h.blog(r).ReviewQueue(editor)
+	if errors.Is(err, domain.ErrNotEditor) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]postResponse, 0, len(posts))
+	for _, p := range posts {
+		resp = append(resp, toPostResponse(&domain.PostView{BlogPost: p}))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"posts": resp})
+}
+
+// ReviewComments returns the editors' comments on ?post_id= to its author
+// or an editor.
+func (h *BlogHandler) ReviewComments(w http.ResponseWriter, r *http.Request) {
+	postID, _ := strconv.ParseUint(r.URL.Query().Get("post_id"), 10, 64)
+	if postID == 0 {
+		http.Error(w, "post_id is required", http.StatusBadRequest)
+		return
+	}
+
+	comments, err := h.blog(r).ReviewComments(viewerFromRequest(r), uint(postID))
+	if err != nil {
+		http.Error(w, err.Error(), reviewStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"comments": comments})
+}
+
+// PublishPost makes one of the caller's approved posts, or a draft when
+// reviews are not required, public. Callers with post:publish:any may
+// publish anyone's.
+func (h *BlogHandler) PublishPost(w http.ResponseWriter, r *http.Request) {
+	viewer, ok := editorFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		PostID uint `json:"post_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.blog(r).PublishPost(viewer, req.PostID); err != nil {
+		http.Error(w, err.Error(), reviewStatus(err))
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"status": domain.PostStatusPublished})
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/seo"
+)
+
+// PostMeta serves the search and social preview markup of a post, by ?id=
+// or ?slug=, as a <head> fragment or, with ?format=json, as JSON including
+// the JSON-LD BlogPosting object.
+func (h *BlogHandler) PostMeta(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	id, _ := strconv.ParseUint(q.Get("id"), 10, 64)
+	slug := q.Get("slug")
+	if id == 0 && slug == "" {
+		http.Error(w, "id or slug is required", http.StatusBadRequest)
+		return
+	}
+
+	meta, err := h.blog(r).PostMeta(viewerFromRequest(r), uint(id), slug, preferredLocales(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Add("Vary", "Accept-Language")
+
+	switch q.Get("format") {
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"meta": meta, "json_ld": meta.BlogPosting()})
+	case "", "html":
+		head, err := seo.Head(*meta)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(head))
+	default:
+		http.Error(w, "format must be html or json", http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/micropub"
+)
+
+type MediaHandler struct {
+	usecase *usecase.MediaUsecase
+}
+
+func NewMediaHandler(u *usecase.MediaUsecase) *MediaHandler {
+	return &MediaHandler{usecase: u}
+}
+
+// Serve serves the uploaded file ?id=.
+func (h *MediaHandler) Serve(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+	media, err := h.usecase.Get(uint(id))
+	if errors.Is(err, domain.ErrMediaNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", media.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(media.Data)))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": media.Name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Write(media.Data)
+}
+
+// Upload is the Micropub media endpoint, for writers: it stores the
+// multipart "file" and answers 201 with its URL in Location.
+func (h *MediaHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value("user_id").(uint)
+
+	name, data, err := micropub.MediaFile(r, domain.MaxMediaSize)
+	if err != nil {
+		writeMicropubError(w, err)
+		return
+	}
+	media, err := h.usecase.Upload(userID, name, data)
+	if err != nil {
+		writeMicropubError(w, err)
+		return
+	}
+	w.Header().Set("Location", h.usecase.URL(media))
+	w.WriteHeader(http.StatusCreated)
+}
@@ -0,0 +1,130 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/payment"
+)
+
+type MembershipHandler struct {
+	usecase *usecase.MembershipUsecase
+}
+
+func NewMembershipHandler(u *usecase.MembershipUsecase) *MembershipHandler {
+	return &MembershipHandler{usecase: u}
+}
+
+// Entitlements is the admin API for membership tiers: GET lists a user's
+// entitlements (?user_id=), POST grants one and DELETE revokes ?id=.
+func (h *MembershipHandler) Entitlements(w http.ResponseWriter, r *http.Request) {
+	admin := viewerFromRequest(r)
+
+	switch r.Method {
+	case http.MethodGet:
+		userID, _ := strconv.ParseUint(r.URL.Query().Get("user_id"), 10, 64)
+		entitlements, err := h.usecase.Entitlements(admin, uint(userID))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"entitlements": entitlements})
+
+	case http.MethodPost:
+		var req struct {
+			UserID    uint       `json:"user_id"`
+			Tier      string     `json:"tier"`
+			ExpiresAt *time.Time `json:"expires_at"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		e, err := h.usecase.Grant(admin, req.UserID, req.Tier, req.ExpiresAt)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(e)
+
+	case http.MethodDelete:
+		id, _ := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+		if err := h.usecase.Revoke(admin, uint(id)); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Membership returns the caller's tier and entitlements.
+func (h *MembershipHandler) Membership(w http.ResponseWriter, r *http.Request) {
+	viewer := viewerFromRequest(r)
+
+	tier, err := h.usecase.Tier(viewer.UserID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	entitlements, err := h.usecase.Entitlements(viewer, viewer.UserID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{"tier": tier, "entitlements": entitlements})
+}
+
+// Checkout starts the purchase of a tier and returns the payment page.
+func (h *MembershipHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	viewer := viewerFromRequest(r)
+
+	var req struct {
+		Tier string `json:"tier"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	c, err := h.usecase.StartCheckout(viewer.UserID, req.Tier)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"session_id": c.SessionID, "url": c.URL})
+}
+
+// CompleteCheckout grants the tier of a paid checkout session. The new tier
+// is in the JWT from the next login on.
+func (h *MembershipHandler) CompleteCheckout(w http.ResponseWriter, r *http.Request) {
+	viewer := viewerFromRequest(r)
+
+	var req struct {
+		SessionID string `json:"session_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	e, err := h.usecase.CompleteCheckout(viewer.UserID, req.SessionID)
+	if errors.Is(err, payment.ErrNotPaid) {
+		http.Error(w, err.Error(), http.StatusPaymentRequired)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(e)
+}
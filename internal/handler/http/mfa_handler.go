@@ -0,0 +1,110 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+)
+
+// LoginMFA finishes a login that returned an mfa_token.
+func (h *UserHandler) LoginMFA(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		MFAToken string `json:"mfa_token"`
+		Code     string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.MFAToken == "" || req.Code == "" {
+		http.Error(w, "mfa_token and code are required", http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := h.usecase.LoginMFA(req.MFAToken, req.Code, clientIP(r))
+	if err != nil {
+		writeLoginError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+	})
+}
+
+// TOTP manages the caller's authenticator: POST starts an enrollment and
+// returns its secret and otpauth:// URI, DELETE turns 2FA off given a
+// {"code"}.
+func (h *UserHandler) TOTP(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value("user_id").(uint)
+
+	switch r.Method {
+	case http.MethodPost:
+		secret, uri, err := h.usecase.EnrollTOTP(userID)
+		if err != nil {
+			writeMFAError(w, err)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"secret": secret,
+			"uri":    uri,
+		})
+
+	case http.MethodDelete:
+		var req struct {
+			Code string `json:"code"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+			http.Error(w, "code is required", http.StatusBadRequest)
+			return
+		}
+		if err := h.usecase.DisableTOTP(userID, req.Code); err != nil {
+			writeMFAError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// ConfirmTOTP enables 2FA with a first {"code"} from the enrolled
+// authenticator and returns the recovery codes.
+func (h *UserHandler) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	userID, _ := r.Context().Value("user_id").(uint)
+
+	var req struct {
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		http.Error(w, "code is required", http.StatusBadRequest)
+		return
+	}
+
+	codes, err := h.usecase.ConfirmTOTP(userID, req.Code)
+	if err != nil {
+		writeMFAError(w, err)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{"recovery_codes": codes})
+}
+
+func writeMFAError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidMFACode):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, domain.ErrMFANotEnrolled), errors.Is(err, domain.ErrMFAAlreadyEnabled):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
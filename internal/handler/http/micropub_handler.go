@@ -0,0 +1,105 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/micropub"
+)
+
+// writeMicropubError answers with a Micropub error document.
+func writeMicropubError(w http.ResponseWriter, err error) {
+	var mpErr *micropub.Error
+	switch {
+	case errors.As(err, &mpErr):
+	case errors.Is(err, domain.ErrPostNotFound):
+		mpErr = micropub.InvalidRequest("post not found")
+	case errors.Is(err, domain.ErrNotMember), errors.Is(err, domain.ErrEmailNotVerified),
+		errors.Is(err, domain.ErrMediaQuotaExceeded):
+		mpErr = &micropub.Error{Code: "forbidden", Description: err.Error()}
+	default:
+		mpErr = micropub.InvalidRequest("%s", err.Error())
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(mpErr.Status())
+	json.NewEncoder(w).Encode(mpErr)
+}
+
+// Micropub is the Micropub endpoint. GET answers ?q=config, ?q=source
+// (?url=, ?properties[]=) and ?q=syndicate-to; POST creates, updates and
+// deletes posts of the calling author.
+func (h *BlogHandler) Micropub(w http.ResponseWriter, r *http.Request) {
+	viewer := viewerFromRequest(r)
+	if !viewer.Can(domain.PermPostCreate) {
+		writeMicropubError(w, &micropub.Error{Code: "forbidden", Description: "user is not an author"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.micropubQuery(w, r, viewer.UserID)
+	case http.MethodPost:
+		h.micropubAction(w, r, viewer.UserID)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *BlogHandler) micropubQuery(w http.ResponseWriter, r *http.Request, userID uint) {
+	q := r.URL.Query()
+	var resp any
+	switch q.Get("q") {
+	case "config":
+		resp = h.blog(r).MicropubConfig()
+	case "syndicate-to":
+		resp = map[string][]any{"syndicate-to": {}}
+	case "source":
+		properties := append(q["properties[]"], q["properties"]...)
+		entry, err := h.blog(r).MicropubSource(userID, q.Get("url"), properties)
+		if err != nil {
+			writeMicropubError(w, err)
+			return
+		}
+		resp = entry
+	default:
+		writeMicropubError(w, micropub.InvalidRequest("unsupported query %q", q.Get("q")))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *BlogHandler) micropubAction(w http.ResponseWriter, r *http.Request, userID uint) {
+	req, err := micropub.Parse(r)
+	if err != nil {
+		writeMicropubError(w, err)
+		return
+	}
+
+	switch req.Action {
+	case micropub.ActionCreate:
+		location, err := h.blog(r).MicropubCreate(userID, req)
+		if err != nil {
+			writeMicropubError(w, err)
+			return
+		}
+		w.Header().Set("Location", location)
+		w.WriteHeader(http.StatusCreated)
+	case micropub.ActionUpdate:
+		if err := h.blog(r).MicropubUpdate(userID, req); err != nil {
+			writeMicropubError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case micropub.ActionDelete:
+		if err := h.blog(r).MicropubDelete(userID, req.URL); err != nil {
+			writeMicropubError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMicropubError(w, micropub.InvalidRequest("deleted posts cannot be restored"))
+	}
+}
@@ -0,0 +1,118 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
+)
+
+type NewsletterHandler struct {
+	usecase *usecase.NewsletterUsecase
+}
+
+func NewNewsletterHandler(u *usecase.NewsletterUsecase) *NewsletterHandler {
+	return &NewsletterHandler{usecase: u}
+}
+
+// Subscribe starts a subscription to all posts, or to one author's posts
+// with "author_id", and mails a confirmation link.
+func (h *NewsletterHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Email    string `json:"email"`
+		AuthorID uint   `json:"author_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err := h.usecase.Subscribe(req.Email, req.AuthorID, clientIP(r))
+	if errors.Is(err, domain.ErrInvalidEmail) || errors.Is(err, domain.ErrAuthorNotFound) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, domain.ErrSubscribeThrottled) {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"status": "check your inbox to confirm"})
+}
+
+// Confirm is the target of the confirmation link, ?token=.
+func (h *NewsletterHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	err := h.usecase.Confirm(r.URL.Query().Get("token"))
+	if errors.Is(err, domain.ErrInvalidConfirmToken) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Write([]byte("Your subscription is confirmed.\n"))
+}
+
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Unsubscribe</title></head>
+<body>
+<form method="post" action="{{.}}">
+<p>Stop receiving new posts by email?</p>
+<button type="submit">Unsubscribe</button>
+</form>
+</body>
+</html>
+`))
+
+// Unsubscribe is the target of the signed ?id=&sig= link in every
+// newsletter. Opened in a browser (GET) it only asks for confirmation, so
+// that link scanners and prefetchers don't unsubscribe anyone; the form, and
+// the mail client's one-click unsubscribe (RFC 8058), POST to it.
+func (h *NewsletterHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	id, _ := strconv.ParseUint(q.Get("id"), 10, 64)
+
+	if r.Method == http.MethodGet {
+		if err := h.usecase.CheckUnsubscribe(uint(id), q.Get("sig")); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		unsubscribePage.Execute(w, r.URL.RequestURI())
+		return
+	}
+
+	err := h.usecase.Unsubscribe(uint(id), q.Get("sig"))
+	switch {
+	case errors.Is(err, domain.ErrInvalidUnsubscribe):
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	case errors.Is(err, domain.ErrSubscriptionNotFound):
+		// Already unsubscribed: the link keeps working.
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Write([]byte("You have been unsubscribed.\n"))
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/oembed"
+)
+
+// OEmbed is the oEmbed provider endpoint for post URLs: ?url= is required,
+// ?format= is json (default) or xml, and ?maxwidth= / ?maxheight= bound the
+// embed's size.
+func (h *BlogHandler) OEmbed(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("url") == "" {
+		http.Error(w, "url is required", http.StatusBadRequest)
+		return
+	}
+	format := q.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "xml" {
+		http.Error(w, "format must be json or xml", http.StatusNotImplemented)
+		return
+	}
+	maxWidth, _ := strconv.Atoi(q.Get("maxwidth"))
+	maxHeight, _ := strconv.Atoi(q.Get("maxheight"))
+
+	resp, err := h.blog(r).OEmbed(q.Get("url"), maxWidth, maxHeight)
+	if errors.Is(err, oembed.ErrTooSmall) {
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	if format == "xml" {
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		w.Write([]byte(xml.Header))
+		xml.NewEncoder(w).Encode(resp)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
+)
+
+type ProfileHandler struct {
+	usecase *usecase.ProfileUsecase
+}
+
+func NewProfileHandler(u *usecase.ProfileUsecase) *ProfileHandler {
+	return &ProfileHandler{usecase: u}
+}
+
+type profileResponse struct {
+	UserID        uint   `json:"user_id"`
+	DisplayName   string `json:"display_name"`
+	Bio           string `json:"bio"`
+	AvatarMediaID uint   `json:"avatar_media_id,omitempty"`
+	AvatarURL     string `json:"avatar_url,omitempty"`
+	Website       string `json:"website"`
+}
+
+func (h *ProfileHandler) toProfileResponse(user *domain.User) profileResponse {
+	return profileResponse{
+		UserID:        user.ID,
+		DisplayName:   user.Profile.DisplayName,
+		Bio:           user.Profile.Bio,
+		AvatarMediaID: user.Profile.AvatarMediaID,
+		AvatarURL:     h.usecase.AvatarURL(user.Profile),
+		Website:       user.Profile.Website,
+	}
+}
+
+// Profile serves the profile of ?user_id= (the caller's own without it) on
+// GET, and updates the caller's profile on PUT. Fields left out of a PUT
+// are unchanged; "avatar_media_id": 0 removes the avatar.
+func (h *ProfileHandler) Profile(w http.ResponseWriter, r *http.Request) {
+	callerID, _ := r.Context().Value("user_id").(uint)
+
+	var user *domain.User
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		userID := callerID
+		if v := r.URL.Query().Get("user_id"); v != "" {
+			id, _ := strconv.ParseUint(v, 10, 64)
+			userID = uint(id)
+		}
+		if userID == 0 {
+			http.Error(w, "user_id is required", http.StatusBadRequest)
+			return
+		}
+		user, err = h.usecase.GetByID(userID)
+
+	case http.MethodPut:
+		if callerID == 0 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var req struct {
+			DisplayName   *string `json:"display_name"`
+			Bio           *string `json:"bio"`
+			AvatarMediaID *uint   `json:"avatar_media_id"`
+			Website       *string `json:"website"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		user, err = h.usecase.UpdateProfile(callerID, domain.ProfileChanges{
+			DisplayName:   req.DisplayName,
+			Bio:           req.Bio,
+			AvatarMediaID: req.AvatarMediaID,
+			Website:       req.Website,
+		})
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.toProfileResponse(user))
+}
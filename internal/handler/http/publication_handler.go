@@ -0,0 +1,230 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
+)
+
+// publicationFromRequest resolves the publication a request is for: the
+// X-Publication-ID header or ?publication_id= if set, else the Host. A nil
+// publication is the default one.
+func publicationFromRequest(pubs *usecase.PublicationUsecase, r *http.Request) (*domain.Publication, error) {
+	raw := r.Header.Get("X-Publication-ID")
+	if raw == "" {
+		raw = r.URL.Query().Get("publication_id")
+	}
+
+	var id uint64
+	if raw != "" {
+		var err error
+		if id, err = strconv.ParseUint(raw, 10, 64); err != nil {
+			return nil, errors.New("invalid publication id")
+		}
+	}
+	return pubs.Resolve(r.Host, uint(id))
+}
+
+// WithPublication resolves the request's publication for the blog
+// handlers, answering 404 for an unknown explicit publication.
+func (h *BlogHandler) WithPublication(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pub, err := publicationFromRequest(h.publications, r)
+		if errors.Is(err, domain.ErrPublicationNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "publication", pub)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// blog returns the blog usecase scoped to the request's publication.
+func (h *BlogHandler) blog(r *http.Request) *usecase.BlogUsecase {
+	pub, _ := r.Context().Value("publication").(*domain.Publication)
+	return h.usecase.ForPublication(pub)
+}
+
+type PublicationHandler struct {
+	usecase *usecase.PublicationUsecase
+}
+
+func NewPublicationHandler(u *usecase.PublicationUsecase) *PublicationHandler {
+	return &PublicationHandler{usecase: u}
+}
+
+// publicationStatus maps publication errors to HTTP status codes.
+func publicationStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrPublicationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrNotPublicationOwner), errors.Is(err, domain.ErrNotMember):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrDomainTaken):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func publicationID(r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+	return uint(id), err == nil && id != 0
+}
+
+// CreatePublication starts a publication owned by the caller.
+func (h *PublicationHandler) CreatePublication(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	viewer := viewerFromRequest(r)
+
+	var req struct {
+		Name         string `json:"name"`
+		Slug         string `json:"slug"`
+		CustomDomain string `json:"custom_domain"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	pub, err := h.usecase.Create(viewer.UserID, req.Name, req.Slug, req.CustomDomain)
+	if err != nil {
+		http.Error(w, err.Error(), publicationStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(pub)
+}
+
+// Publication returns (GET) or, for owners, updates (PUT) publication ?id=.
+func (h *PublicationHandler) Publication(w http.ResponseWriter, r *http.Request) {
+	id, ok := publicationID(r)
+	if !ok {
+		http.Error(w, "invalid publication id", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		pub, err := h.usecase.Get(id)
+		if err != nil {
+			http.Error(w, err.Error(), publicationStatus(err))
+			return
+		}
+		json.NewEncoder(w).Encode(pub)
+
+	case http.MethodPut:
+		var req struct {
+			Name         *string                     `json:"name"`
+			Description  *string                     `json:"description"`
+			CustomDomain *string                     `json:"custom_domain"`
+			Settings     *domain.PublicationSettings `json:"settings"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		pub, err := h.usecase.Update(viewerFromRequest(r).UserID, id, usecase.PublicationChanges{
+			Name:         req.Name,
+			Description:  req.Description,
+			CustomDomain: req.CustomDomain,
+			Settings:     req.Settings,
+		})
+		if err != nil {
+			http.Error(w, err.Error(), publicationStatus(err))
+			return
+		}
+		json.NewEncoder(w).Encode(pub)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// VerifyDomain activates the pending custom domain of publication ?id= once
+// its DNS verification record is in place.
+func (h *PublicationHandler) VerifyDomain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	id, ok := publicationID(r)
+	if !ok {
+		http.Error(w, "invalid publication id", http.StatusBadRequest)
+		return
+	}
+
+	pub, err := h.usecase.VerifyDomain(viewerFromRequest(r).UserID, id)
+	if err != nil {
+		http.Error(w, err.Error(), publicationStatus(err))
+		return
+	}
+	json.NewEncoder(w).Encode(pub)
+}
+
+// Members lists (GET), adds or changes (POST) and removes (DELETE
+// ?user_id=) the members of publication ?id=.
+func (h *PublicationHandler) Members(w http.ResponseWriter, r *http.Request) {
+	id, ok := publicationID(r)
+	if !ok {
+		http.Error(w, "invalid publication id", http.StatusBadRequest)
+		return
+	}
+	viewer := viewerFromRequest(r)
+
+	switch r.Method {
+	case http.MethodGet:
+		members, err := h.usecase.Members(viewer.UserID, id)
+		if err != nil {
+			http.Error(w, err.Error(), publicationStatus(err))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"members": members})
+
+	case http.MethodPost:
+		var req struct {
+			UserID uint   `json:"user_id"`
+			Role   string `json:"role"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		member, err := h.usecase.SetMember(viewer.UserID, id, req.UserID, req.Role)
+		if err != nil {
+			http.Error(w, err.Error(), publicationStatus(err))
+			return
+		}
+		json.NewEncoder(w).Encode(member)
+
+	case http.MethodDelete:
+		userID, err := strconv.ParseUint(r.URL.Query().Get("user_id"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid user id", http.StatusBadRequest)
+			return
+		}
+		if err := h.usecase.RemoveMember(viewer.UserID, id, uint(userID)); err != nil {
+			http.Error(w, err.Error(), publicationStatus(err))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"status": "removed"})
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
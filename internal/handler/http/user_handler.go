@@ -2,8 +2,12 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"net"
 	"net/http"
+	"strconv"
 
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
 )
 
@@ -51,17 +55,324 @@ func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.usecase.Login(req.Email, req.Password)
+	tokens, err := h.usecase.Login(req.Email, req.Password, clientIP(r))
 	if err != nil {
+		writeLoginError(w, err)
+		return
+	}
+
+	if tokens.MFAToken != "" {
+		json.NewEncoder(w).Encode(map[string]any{
+			"mfa_required": true,
+			"mfa_token":    tokens.MFAToken,
+		})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+	})
+}
+
+// writeLoginError answers a failed login.
+func writeLoginError(w http.ResponseWriter, err error) {
+	var throttled *domain.LoginThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		w.Header().Set("Retry-After", strconv.Itoa(int(throttled.RetryAfter.Seconds())+1))
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+	case errors.Is(err, domain.ErrUserBanned):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, domain.ErrInvalidCredentials),
+		errors.Is(err, domain.ErrInvalidMFACode),
+		errors.Is(err, domain.ErrInvalidMFAChallenge):
 		http.Error(w, err.Error(), http.StatusUnauthorized)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// clientIP is the address the request came from. Forwarding headers are
+// not trusted, as nothing guarantees a proxy in front sets them.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Refresh exchanges a refresh token for a new token pair; the old refresh
+// token stops working.
+func (h *UserHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		http.Error(w, "refresh_token is required", http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := h.usecase.Refresh(req.RefreshToken)
+	if errors.Is(err, domain.ErrInvalidRefreshToken) || errors.Is(err, domain.ErrRefreshTokenReused) {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	json.NewEncoder(w).Encode(map[string]string{
-		"token": token,
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
 	})
 }
 
+// Logout revokes the session of the caller's token.
+func (h *UserHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	userID, _ := r.Context().Value("user_id").(uint)
+	session, _ := r.Context().Value("session_id").(string)
+
+	if err := h.usecase.Logout(userID, session); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// LogoutEverywhere revokes every session of the caller.
+func (h *UserHandler) LogoutEverywhere(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	userID, _ := r.Context().Value("user_id").(uint)
+
+	if err := h.usecase.LogoutEverywhere(userID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// VerifyEmail handles the link of a verification email.
+func (h *UserHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	userID, err := strconv.ParseUint(q.Get("user_id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid user_id", http.StatusBadRequest)
+		return
+	}
+	expires, err := strconv.ParseInt(q.Get("expires"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid expires", http.StatusBadRequest)
+		return
+	}
+
+	err = h.usecase.VerifyEmail(uint(userID), expires, q.Get("sig"))
+	if errors.Is(err, domain.ErrInvalidVerification) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "email address verified",
+	})
+}
+
+// ResendVerification emails the caller a new verification link.
+func (h *UserHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	userID, _ := r.Context().Value("user_id").(uint)
+
+	err := h.usecase.ResendVerification(userID)
+	switch {
+	case errors.Is(err, domain.ErrVerificationThrottled):
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	case errors.Is(err, domain.ErrEmailAlreadyVerified):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ForgotPassword emails a reset link. It answers the same whether or not
+// the email has an account.
+func (h *UserHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+		http.Error(w, "email is required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.usecase.ForgotPassword(req.Email, clientIP(r))
+	if errors.Is(err, domain.ErrResetThrottled) {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "if the email has an account, a reset link is on its way",
+	})
+}
+
+// ResetPassword sets a new password with the token of a reset link.
+func (h *UserHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Token    string `json:"token"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Token == "" || req.Password == "" {
+		http.Error(w, "token and password are required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.usecase.ResetPassword(req.Token, req.Password)
+	if errors.Is(err, domain.ErrInvalidResetToken) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "password reset, please log in again",
+	})
+}
+
+// UnlockLogin is the admin API lifting the failed-login block of an
+// account ({"email"}) or a client address ({"ip"}).
+func (h *UserHandler) UnlockLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	admin := viewerFromRequest(r)
+
+	var req struct {
+		Email string `json:"email"`
+		IP    string `json:"ip"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.usecase.UnlockLogin(admin, req.Email, req.IP); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// Roles is the admin API for staff roles: GET lists the roles of ?user_id=,
+// PUT replaces a user's staff roles with {"user_id", "roles"}.
+func (h *UserHandler) Roles(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		userID, _ := strconv.ParseUint(r.URL.Query().Get("user_id"), 10, 64)
+		roles, err := h.usecase.Roles(uint(userID))
+		if err != nil {
+			http.Error(w, err.Error(), adminStatus(err))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"user_id": userID, "roles": roles})
+
+	case http.MethodPut:
+		var req struct {
+			UserID uint     `json:"user_id"`
+			Roles  []string `json:"roles"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if err := h.usecase.SetRoles(viewerFromRequest(r), req.UserID, req.Roles); err != nil {
+			http.Error(w, err.Error(), adminStatus(err))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Ban suspends {"user_id"}, or reinstates them with "banned": false.
+func (h *UserHandler) Ban(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		UserID uint  `json:"user_id"`
+		Banned *bool `json:"banned"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	banned := req.Banned == nil || *req.Banned
+
+	if err := h.usecase.SetBanned(viewerFromRequest(r), req.UserID, banned); err != nil {
+		http.Error(w, err.Error(), adminStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// adminStatus maps errors of the admin APIs to HTTP status codes.
+func adminStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrPermissionDenied):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrUserNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
+
 func (h *UserHandler) PromoteToAuthor(w http.ResponseWriter, r *http.Request) {
 	// Get userID from context (set by JWT middleware)
 	userIDValue := r.Context().Value("user_id")
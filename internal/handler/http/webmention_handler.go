@@ -0,0 +1,43 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/usecase"
+)
+
+type WebmentionHandler struct {
+	usecase *usecase.WebmentionUsecase
+}
+
+func NewWebmentionHandler(u *usecase.WebmentionUsecase) *WebmentionHandler {
+	return &WebmentionHandler{usecase: u}
+}
+
+// Webmention receives a webmention, the form fields source and target. It
+// is accepted for asynchronous verification when target is a post of the
+// publication.
+func (h *WebmentionHandler) Webmention(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	pub, _ := r.Context().Value("publication").(*domain.Publication)
+	err := h.usecase.Receive(pub, r.FormValue("source"), r.FormValue("target"), clientIP(r))
+	if errors.Is(err, domain.ErrInvalidWebmention) || errors.Is(err, domain.ErrPostNotFound) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, domain.ErrWebmentionThrottled) {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
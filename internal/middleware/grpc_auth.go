@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryOptionalAuth is the gRPC counterpart of OptionalAuth: a valid bearer
+// token in the "authorization" metadata identifies the caller, anything else
+// leaves the call anonymous.
+func (m *AuthMiddleware) UnaryOptionalAuth() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(m.optionalGRPCAuth(ctx), req)
+	}
+}
+
+// StreamOptionalAuth is UnaryOptionalAuth for streaming RPCs.
+func (m *AuthMiddleware) StreamOptionalAuth() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &authStream{ServerStream: ss, ctx: m.optionalGRPCAuth(ss.Context())})
+	}
+}
+
+// UnaryRequirePermission is the gRPC counterpart of RequirePermission. It
+// guards the methods listed in perms, keyed by full method name such as
+// "/user.UserService/SetRoles", and must run after UnaryOptionalAuth.
+func UnaryRequirePermission(perms map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		perm, ok := perms[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+		if userID, _ := ctx.Value("user_id").(uint); userID == 0 {
+			return nil, status.Error(codes.Unauthenticated, "unauthorized")
+		}
+		roles, _ := ctx.Value("user_roles").([]string)
+		if !domain.HasPermission(roles, perm) {
+			return nil, status.Error(codes.PermissionDenied, domain.ErrPermissionDenied.Error())
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (m *AuthMiddleware) optionalGRPCAuth(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get("authorization")) == 0 {
+		return ctx
+	}
+
+	authCtx, err := m.authenticate(ctx, md.Get("authorization")[0])
+	if err != nil {
+		return ctx
+	}
+	return authCtx
+}
+
+// authStream overrides the context of a server stream.
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
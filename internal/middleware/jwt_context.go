@@ -2,18 +2,33 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
 	"github.com/Hamiduzzaman96/Blog-Service/pkg/jwt"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/redis"
 )
 
+// revocationCacheTTL is how long a token found valid in Redis is trusted
+// without asking again, and so how long a revoked token may still work.
+const revocationCacheTTL = 5 * time.Second
+
 type AuthMiddleware struct {
 	jwtService *jwt.Service
+	tokens     *redis.Client
+
+	mu    sync.Mutex
+	valid map[string]time.Time // jti -> when to check Redis again
 }
 
-func NewAuthMiddleware(jwtSvc *jwt.Service) *AuthMiddleware {
-	return &AuthMiddleware{jwtService: jwtSvc}
+// NewAuthMiddleware accepts access tokens signed by jwtSvc that have not
+// been revoked in tokens.
+func NewAuthMiddleware(jwtSvc *jwt.Service, tokens *redis.Client) *AuthMiddleware {
+	return &AuthMiddleware{jwtService: jwtSvc, tokens: tokens, valid: make(map[string]time.Time)}
 }
 
 func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
@@ -24,33 +39,128 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 			return
 		}
 
-		parts := strings.Split(tokenStr, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			http.Error(w, "invalid token format", http.StatusUnauthorized)
+		ctx, err := m.authenticate(r.Context(), tokenStr)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
 		}
 
-		claims, err := m.jwtService.Validate(parts[1])
-		if err != nil {
-			http.Error(w, "invalid token", http.StatusUnauthorized)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequirePermission is RequireAuth for callers whose roles grant perm;
+// others are refused with 403.
+func (m *AuthMiddleware) RequirePermission(perm string, next http.Handler) http.Handler {
+	return m.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roles, _ := r.Context().Value("user_roles").([]string)
+		if !domain.HasPermission(roles, perm) {
+			http.Error(w, domain.ErrPermissionDenied.Error(), http.StatusForbidden)
 			return
 		}
+		next.ServeHTTP(w, r)
+	}))
+}
 
-		userIDFloat, ok := (*claims)["user_id"].(float64)
-		if !ok {
-			http.Error(w, "user_id not found in token", http.StatusUnauthorized)
-			return
+// OptionalAuth identifies the caller when a valid bearer token is sent and
+// otherwise lets the request through anonymously.
+func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenStr := r.Header.Get("Authorization"); tokenStr != "" {
+			if ctx, err := m.authenticate(r.Context(), tokenStr); err == nil {
+				r = r.WithContext(ctx)
+			}
 		}
+		next.ServeHTTP(w, r)
+	})
+}
 
-		role, ok := (*claims)["role"].(string)
-		if !ok {
-			http.Error(w, "role not found in token", http.StatusUnauthorized)
-			return
+// authenticate validates an "Authorization: Bearer <token>" value and stores
+// the caller's user_id, user_roles, user_tier and session_id in the returned
+// context.
+func (m *AuthMiddleware) authenticate(ctx context.Context, header string) (context.Context, error) {
+	parts := strings.Split(header, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, errors.New("invalid token format")
+	}
+
+	claims, err := m.jwtService.Validate(parts[1])
+	if err != nil {
+		return nil, errors.New("invalid token")
+	}
+
+	jti, _ := (*claims)["jti"].(string)
+	session, _ := (*claims)["sid"].(string)
+	if err := m.checkRevoked(jti); err != nil {
+		return nil, err
+	}
+
+	userIDFloat, ok := (*claims)["user_id"].(float64)
+	if !ok {
+		return nil, errors.New("user_id not found in token")
+	}
+
+	role, ok := (*claims)["role"].(string)
+	if !ok {
+		return nil, errors.New("role not found in token")
+	}
+	// Tokens issued before staff roles carry only the main role.
+	roles := []string{role}
+	if list, ok := (*claims)["roles"].([]any); ok {
+		roles = roles[:0]
+		for _, r := range list {
+			if s, ok := r.(string); ok {
+				roles = append(roles, s)
+			}
 		}
+	}
 
-		ctx := context.WithValue(r.Context(), "user_id", uint(userIDFloat))
-		ctx = context.WithValue(ctx, "user_role", role)
+	// Tokens issued before membership tiers carry no tier.
+	tier, ok := (*claims)["tier"].(string)
+	if !ok || tier == "" {
+		tier = domain.TierFree
+	}
 
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
+	ctx = context.WithValue(ctx, "user_id", uint(userIDFloat))
+	ctx = context.WithValue(ctx, "user_roles", roles)
+	ctx = context.WithValue(ctx, "user_tier", tier)
+	ctx = context.WithValue(ctx, "session_id", session)
+	return ctx, nil
+}
+
+// checkRevoked accepts only tokens still listed in Redis. Tokens without a
+// jti predate revocation and are rejected.
+func (m *AuthMiddleware) checkRevoked(jti string) error {
+	if jti == "" {
+		return errors.New("token has been revoked")
+	}
+
+	now := time.Now()
+	m.mu.Lock()
+	until, ok := m.valid[jti]
+	m.mu.Unlock()
+	if ok && now.Before(until) {
+		return nil
+	}
+
+	_, found, err := m.tokens.ValidateToken(jti)
+	if err != nil {
+		return errors.New("could not check token")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !found {
+		delete(m.valid, jti)
+		return errors.New("token has been revoked")
+	}
+	if len(m.valid) > 10000 {
+		for k, t := range m.valid {
+			if now.After(t) {
+				delete(m.valid, k)
+			}
+		}
+	}
+	m.valid[jti] = now.Add(revocationCacheTTL)
+	return nil
 }
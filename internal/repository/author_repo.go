@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"errors"
+
 	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
 	"gorm.io/gorm"
 )
@@ -46,6 +48,18 @@ func (r *AuthorRepository) Create(a *domain.Author) (*domain.Author, error) {
 	return a, nil
 }
 
+func (r *AuthorRepository) FindByID(id uint) (*domain.Author, error) {
+	var m AuthorModel
+
+	if err := r.db.First(&m, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAuthorNotFound
+		}
+		return nil, err
+	}
+	return authorModelToDomain(&m), nil
+}
+
 func (r *AuthorRepository) FindByUserID(userID uint) (*domain.Author, error) {
 	var m AuthorModel
 
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AutosaveRepository struct {
+	db *gorm.DB
+}
+
+func NewAutosaveRepository(db *gorm.DB) *AutosaveRepository {
+	return &AutosaveRepository{db: db}
+}
+
+func (r *AutosaveRepository) Migrate() error {
+	return r.db.AutoMigrate(&PostAutosaveModel{})
+}
+
+// MAPPERS
+
+func autosaveModelToDomain(m *PostAutosaveModel) *domain.Autosave {
+	return &domain.Autosave{
+		UserID:  m.UserID,
+		PostID:  m.PostID,
+		Title:   m.Title,
+		Content: m.Content,
+		Hash:    m.Hash,
+		SavedAt: m.UpdatedAt,
+	}
+}
+
+// CRUD
+
+// Find returns the user's autosave for a post, or nil if there is none.
+func (r *AutosaveRepository) Find(userID, postID uint) (*domain.Autosave, error) {
+	var m PostAutosaveModel
+	err := r.db.Where("user_id = ? AND post_id = ?", userID, postID).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return autosaveModelToDomain(&m), nil
+}
+
+// Save creates or replaces the user's autosave for a post in one upsert, so
+// concurrent saves can't trip over the unique index.
+func (r *AutosaveRepository) Save(a *domain.Autosave) error {
+	m := PostAutosaveModel{
+		UserID:    a.UserID,
+		PostID:    a.PostID,
+		Title:     a.Title,
+		Content:   a.Content,
+		Hash:      a.Hash,
+		UpdatedAt: time.Now(),
+	}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "post_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "content", "hash", "updated_at"}),
+	}).Create(&m).Error
+	if err != nil {
+		return err
+	}
+	a.SavedAt = m.UpdatedAt
+	return nil
+}
+
+func (r *AutosaveRepository) Delete(userID, postID uint) error {
+	return r.db.Where("user_id = ? AND post_id = ?", userID, postID).Delete(&PostAutosaveModel{}).Error
+}
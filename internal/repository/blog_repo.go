@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
 	"gorm.io/gorm"
 )
@@ -14,33 +17,83 @@ func NewBlogRepository(db *gorm.DB) *BlogRepository {
 }
 
 func (r *BlogRepository) Migrate() error { //Database schema ensure
-	return r.db.AutoMigrate(&BlogModel{}) /*GORM এর AutoMigrate:
+	return r.db.AutoMigrate(&BlogModel{}, &PostTranslationModel{}, &ReviewCommentModel{}) /*GORM এর AutoMigrate:
 	যদি table না থাকে → create করে
 	যদি column না থাকে → add করে
-	যদি column type change করা safe হয় → update করে*/
+	যদি column type change করা safe হয় → update করে*/
 }
 
 // MAPPERS
-// func blogModelToDomain(m *BlogModel) *domain.BlogPost {
-// 	return &domain.BlogPost{
-// 		ID:        m.ID,
-// 		AuthorID:  m.AuthorID,
-// 		Title:     m.Title,
-// 		Content:   m.Content,
-// 		CreatedAt: m.CreatedAt,
-// 		UpdatedAt: m.UpdatedAt,
-// 	}
-// }
+func blogModelToDomain(m *BlogModel) *domain.BlogPost {
+	return &domain.BlogPost{
+		ID:            m.ID,
+		PublicationID: m.PublicationID,
+		AuthorID:      m.AuthorID,
+		Slug:          m.Slug,
+		Locale:        m.Locale,
+		Title:         m.Title,
+		Content:       m.Content,
+		Tags:          splitTags(m.Tags),
+		Categories:    splitTags(m.Categories),
+		Status:        m.Status,
+		Visibility:    m.Visibility,
+		RequiredTier:  m.RequiredTier,
+		PublishedAt:   m.PublishedAt,
+		Version:       m.Version,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+		SEO: domain.PostSEO{
+			MetaDescription: m.MetaDescription,
+			CanonicalURL:    m.CanonicalURL,
+			SocialImage:     m.SocialImage,
+			NoIndex:         m.NoIndex,
+		},
+	}
+}
 
 func blogDomainToModel(b *domain.BlogPost) *BlogModel {
 	return &BlogModel{
-		ID:       b.ID,
-		AuthorID: b.AuthorID,
-		Title:    b.Title,
-		Content:  b.Content,
+		ID:              b.ID,
+		PublicationID:   b.PublicationID,
+		AuthorID:        b.AuthorID,
+		Slug:            b.Slug,
+		Locale:          b.Locale,
+		Title:           b.Title,
+		Content:         b.Content,
+		Tags:            strings.Join(b.Tags, ","),
+		Categories:      strings.Join(b.Categories, ","),
+		Status:          b.Status,
+		Visibility:      b.Visibility,
+		RequiredTier:    b.RequiredTier,
+		MetaDescription: b.SEO.MetaDescription,
+		CanonicalURL:    b.SEO.CanonicalURL,
+		SocialImage:     b.SEO.SocialImage,
+		NoIndex:         b.SEO.NoIndex,
+		PublishedAt:     b.PublishedAt,
+		Version:         b.Version,
+		CreatedAt:       b.CreatedAt,
+		UpdatedAt:       b.UpdatedAt,
+	}
+}
+
+func translationModelToDomain(m *PostTranslationModel) *domain.PostTranslation {
+	return &domain.PostTranslation{
+		ID:        m.ID,
+		BlogID:    m.BlogID,
+		Locale:    m.Locale,
+		Title:     m.Title,
+		Content:   m.Content,
+		UpdatedAt: m.UpdatedAt,
 	}
 }
 
+func splitTags(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
 // CRUD
 
 func (r *BlogRepository) Create(b *domain.BlogPost) (*domain.BlogPost, error) {
@@ -52,3 +105,289 @@ func (r *BlogRepository) Create(b *domain.BlogPost) (*domain.BlogPost, error) {
 	b.ID = m.ID
 	return b, nil
 }
+
+// CreateAll stores the posts in one transaction, so either all of them are
+// created or none is.
+func (r *BlogRepository) CreateAll(posts []*domain.BlogPost) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, b := range posts {
+			m := blogDomainToModel(b)
+			if err := tx.Create(m).Error; err != nil {
+				return err
+			}
+			b.ID = m.ID
+		}
+		return nil
+	})
+}
+
+// CreateImported stores an imported post and records it under ref in the
+// same transaction, so an interrupted import never leaves a post that a
+// rerun would create again.
+func (r *BlogRepository) CreateImported(b *domain.BlogPost, ref domain.ImportRef) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		m := blogDomainToModel(b)
+		if err := tx.Create(m).Error; err != nil {
+			return err
+		}
+		b.ID = m.ID
+		return tx.Create(&ImportRecordModel{
+			Source:     ref.Source,
+			Kind:       ref.Kind,
+			ExternalID: ref.ExternalID,
+			LocalID:    m.ID,
+		}).Error
+	})
+}
+
+func (r *BlogRepository) FindByID(id uint) (*domain.BlogPost, error) {
+	var m BlogModel
+	if err := r.db.First(&m, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPostNotFound
+		}
+		return nil, err
+	}
+	return blogModelToDomain(&m), nil
+}
+
+func (r *BlogRepository) FindBySlug(publicationID uint, slug string) (*domain.BlogPost, error) {
+	var m BlogModel
+	if err := r.db.Where("publication_id = ? AND slug = ?", publicationID, slug).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPostNotFound
+		}
+		return nil, err
+	}
+	return blogModelToDomain(&m), nil
+}
+
+// List returns posts of f.PublicationID matching f, newest first.
+func (r *BlogRepository) List(f domain.PostFilter) ([]*domain.BlogPost, error) {
+	q := r.filter(f)
+	if f.Limit > 0 {
+		q = q.Limit(f.Limit)
+	}
+	if f.Offset > 0 {
+		q = q.Offset(f.Offset)
+	}
+
+	var ms []BlogModel
+	if err := q.Order("COALESCE(published_at, created_at) DESC").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	posts := make([]*domain.BlogPost, 0, len(ms))
+	for i := range ms {
+		posts = append(posts, blogModelToDomain(&ms[i]))
+	}
+	return posts, nil
+}
+
+// Count returns the number of posts matching f, ignoring Limit and Offset.
+func (r *BlogRepository) Count(f domain.PostFilter) (int64, error) {
+	var count int64
+	err := r.filter(f).Count(&count).Error
+	return count, err
+}
+
+func (r *BlogRepository) filter(f domain.PostFilter) *gorm.DB {
+	q := r.db.Model(&BlogModel{}).Where("publication_id = ?", f.PublicationID)
+	if f.AuthorID != 0 {
+		q = q.Where("author_id = ?", f.AuthorID)
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+	if len(f.Visibilities) > 0 {
+		if f.OwnerAuthorID != 0 {
+			q = q.Where("(visibility IN ? OR author_id = ?)", f.Visibilities, f.OwnerAuthorID)
+		} else {
+			q = q.Where("visibility IN ?", f.Visibilities)
+		}
+	}
+	if f.Tag != "" {
+		q = q.Where("LOWER(',' || tags || ',') LIKE ?", "%,"+strings.ToLower(f.Tag)+",%")
+	}
+	return q
+}
+
+func (r *BlogRepository) FindByAuthorID(publicationID, authorID uint) ([]*domain.BlogPost, error) {
+	var ms []BlogModel
+	err := r.db.Where("publication_id = ? AND author_id = ?", publicationID, authorID).Order("created_at").Find(&ms).Error
+	if err != nil {
+		return nil, err
+	}
+
+	posts := make([]*domain.BlogPost, 0, len(ms))
+	for i := range ms {
+		posts = append(posts, blogModelToDomain(&ms[i]))
+	}
+	return posts, nil
+}
+
+// Update saves the post's editable fields if its stored version still equals
+// expectedVersion, and bumps the version. A mismatch returns
+// domain.ErrVersionConflict.
+func (r *BlogRepository) Update(b *domain.BlogPost, expectedVersion uint) error {
+	return updatePost(r.db, b, expectedVersion)
+}
+
+// UpdateReviewed saves a post like Update together with the review comments
+// of the decision, in one transaction.
+func (r *BlogRepository) UpdateReviewed(b *domain.BlogPost, expectedVersion uint, comments []domain.ReviewComment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := updatePost(tx, b, expectedVersion); err != nil {
+			return err
+		}
+		return createReviewComments(tx, comments)
+	})
+}
+
+func updatePost(db *gorm.DB, b *domain.BlogPost, expectedVersion uint) error {
+	res := db.Model(&BlogModel{}).
+		Where("id = ? AND version = ?", b.ID, expectedVersion).
+		Updates(map[string]any{
+			"title":            b.Title,
+			"content":          b.Content,
+			"tags":             strings.Join(b.Tags, ","),
+			"categories":       strings.Join(b.Categories, ","),
+			"status":           b.Status,
+			"visibility":       b.Visibility,
+			"required_tier":    b.RequiredTier,
+			"meta_description": b.SEO.MetaDescription,
+			"canonical_url":    b.SEO.CanonicalURL,
+			"social_image":     b.SEO.SocialImage,
+			"no_index":         b.SEO.NoIndex,
+			"published_at":     b.PublishedAt,
+			"updated_at":       b.UpdatedAt,
+			"version":          gorm.Expr("version + 1"),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrVersionConflict
+	}
+
+	b.Version = expectedVersion + 1
+	return nil
+}
+
+// Delete removes a post together with its translations, comments and
+// autosaves.
+func (r *BlogRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("post_id = ?", id).Delete(&PostAutosaveModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("blog_id = ?", id).Delete(&PostTranslationModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("blog_id = ?", id).Delete(&CommentModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("blog_id = ?", id).Delete(&ReviewCommentModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&BlogModel{}, id).Error
+	})
+}
+
+// SlugExists reports whether the slug is taken in the publication. Slugs are
+// only unique per publication.
+func (r *BlogRepository) SlugExists(publicationID uint, slug string) (bool, error) {
+	var count int64
+	err := r.db.Model(&BlogModel{}).Where("publication_id = ? AND slug = ?", publicationID, slug).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// TRANSLATIONS
+
+// UpsertTranslation creates or replaces the translation for (BlogID, Locale).
+func (r *BlogRepository) UpsertTranslation(t *domain.PostTranslation) error {
+	var m PostTranslationModel
+	err := r.db.Where("blog_id = ? AND locale = ?", t.BlogID, t.Locale).First(&m).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	m.BlogID = t.BlogID
+	m.Locale = t.Locale
+	m.Title = t.Title
+	m.Content = t.Content
+	if err := r.db.Save(&m).Error; err != nil {
+		return err
+	}
+	t.ID = m.ID
+	return nil
+}
+
+// FindTranslations returns the translations of the given posts keyed by post ID.
+func (r *BlogRepository) FindTranslations(blogIDs ...uint) (map[uint][]*domain.PostTranslation, error) {
+	out := make(map[uint][]*domain.PostTranslation)
+	if len(blogIDs) == 0 {
+		return out, nil
+	}
+
+	var ms []PostTranslationModel
+	if err := r.db.Where("blog_id IN ?", blogIDs).Order("locale").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+	for i := range ms {
+		out[ms[i].BlogID] = append(out[ms[i].BlogID], translationModelToDomain(&ms[i]))
+	}
+	return out, nil
+}
+
+// REVIEW COMMENTS
+
+func createReviewComments(db *gorm.DB, comments []domain.ReviewComment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ms := make([]ReviewCommentModel, 0, len(comments))
+	for _, c := range comments {
+		ms = append(ms, ReviewCommentModel{
+			BlogID:       c.BlogID,
+			EditorUserID: c.EditorUserID,
+			Quote:        c.Quote,
+			Offset:       c.Offset,
+			Body:         c.Body,
+		})
+	}
+	if err := db.Create(&ms).Error; err != nil {
+		return err
+	}
+
+	for i := range ms {
+		comments[i].ID = ms[i].ID
+		comments[i].CreatedAt = ms[i].CreatedAt
+	}
+	return nil
+}
+
+func (r *BlogRepository) FindReviewComments(blogID uint) ([]domain.ReviewComment, error) {
+	var ms []ReviewCommentModel
+	if err := r.db.Where("blog_id = ?", blogID).Order("created_at, id").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	comments := make([]domain.ReviewComment, 0, len(ms))
+	for _, m := range ms {
+		comments = append(comments, domain.ReviewComment{
+			ID:           m.ID,
+			BlogID:       m.BlogID,
+			EditorUserID: m.EditorUserID,
+			Quote:        m.Quote,
+			Offset:       m.Offset,
+			Body:         m.Body,
+			CreatedAt:    m.CreatedAt,
+		})
+	}
+	return comments, nil
+}
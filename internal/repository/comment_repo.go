@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"gorm.io/gorm"
+)
+
+type CommentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+func (r *CommentRepository) Migrate() error {
+	return r.db.AutoMigrate(&CommentModel{})
+}
+
+// MAPPERS
+
+func commentModelToDomain(m *CommentModel) *domain.Comment {
+	return &domain.Comment{
+		ID:          m.ID,
+		BlogID:      m.BlogID,
+		ParentID:    m.ParentID,
+		UserID:      m.UserID,
+		AuthorName:  m.AuthorName,
+		AuthorEmail: m.AuthorEmail,
+		AuthorURL:   m.AuthorURL,
+		Content:     m.Content,
+		Status:      m.Status,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func commentDomainToModel(c *domain.Comment) *CommentModel {
+	return &CommentModel{
+		ID:          c.ID,
+		BlogID:      c.BlogID,
+		ParentID:    c.ParentID,
+		UserID:      c.UserID,
+		AuthorName:  c.AuthorName,
+		AuthorEmail: c.AuthorEmail,
+		AuthorURL:   c.AuthorURL,
+		Content:     c.Content,
+		Status:      c.Status,
+		CreatedAt:   c.CreatedAt,
+	}
+}
+
+// CRUD
+
+func (r *CommentRepository) Create(c *domain.Comment) (*domain.Comment, error) {
+	m := commentDomainToModel(c)
+
+	if err := r.db.Create(m).Error; err != nil {
+		return nil, err
+	}
+	c.ID = m.ID
+	return c, nil
+}
+
+// CreateImported stores an imported comment together with the import
+// record of its external ID, so a resumed import never creates it twice.
+func (r *CommentRepository) CreateImported(c *domain.Comment, ref domain.ImportRef) (*domain.Comment, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		m := commentDomainToModel(c)
+		if err := tx.Create(m).Error; err != nil {
+			return err
+		}
+		c.ID = m.ID
+		return tx.Create(&ImportRecordModel{
+			Source:     ref.Source,
+			Kind:       ref.Kind,
+			ExternalID: ref.ExternalID,
+			LocalID:    m.ID,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *CommentRepository) Update(c *domain.Comment) error {
+	return r.db.Model(&CommentModel{}).Where("id = ?", c.ID).Updates(map[string]any{
+		"author_name": c.AuthorName,
+		"author_url":  c.AuthorURL,
+		"content":     c.Content,
+	}).Error
+}
+
+func (r *CommentRepository) Delete(id uint) error {
+	return r.db.Delete(&CommentModel{}, id).Error
+}
+
+func (r *CommentRepository) FindByBlogID(blogID uint, status string) ([]*domain.Comment, error) {
+	var ms []CommentModel
+	if err := r.db.Where("blog_id = ? AND status = ?", blogID, status).Order("created_at").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	comments := make([]*domain.Comment, 0, len(ms))
+	for i := range ms {
+		comments = append(comments, commentModelToDomain(&ms[i]))
+	}
+	return comments, nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EmailOutboxRepository stores outgoing email until the mail sender picks
+// it up.
+type EmailOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailOutboxRepository(db *gorm.DB) *EmailOutboxRepository {
+	return &EmailOutboxRepository{db: db}
+}
+
+func (r *EmailOutboxRepository) Migrate() error {
+	return r.db.AutoMigrate(&OutboundEmailModel{})
+}
+
+// MAPPERS
+
+func outboundEmailModelToDomain(m *OutboundEmailModel) *domain.OutboundEmail {
+	return &domain.OutboundEmail{
+		ID:              m.ID,
+		Key:             m.Key,
+		To:              m.To,
+		Subject:         m.Subject,
+		Body:            m.Body,
+		ListUnsubscribe: m.ListUnsubscribe,
+		Sent:            m.Sent,
+		CreatedAt:       m.CreatedAt,
+	}
+}
+
+// CRUD
+
+// Enqueue adds emails to the outbox, skipping any whose key is already there.
+func (r *EmailOutboxRepository) Enqueue(emails ...*domain.OutboundEmail) error {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	ms := make([]OutboundEmailModel, 0, len(emails))
+	for _, e := range emails {
+		ms = append(ms, OutboundEmailModel{
+			Key:             e.Key,
+			To:              e.To,
+			Subject:         e.Subject,
+			Body:            e.Body,
+			ListUnsubscribe: e.ListUnsubscribe,
+		})
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&ms, 500).Error
+}
+
+// FindPending returns up to limit unsent emails, oldest first.
+func (r *EmailOutboxRepository) FindPending(limit int) ([]*domain.OutboundEmail, error) {
+	var ms []OutboundEmailModel
+	if err := r.db.Where("sent = ?", false).Order("id").Limit(limit).Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	emails := make([]*domain.OutboundEmail, 0, len(ms))
+	for i := range ms {
+		emails = append(emails, outboundEmailModelToDomain(&ms[i]))
+	}
+	return emails, nil
+}
+
+func (r *EmailOutboxRepository) MarkAsSent(id uint) error {
+	return r.db.Model(&OutboundEmailModel{}).
+		Where("id = ?", id).
+		Update("sent", true).Error
+}
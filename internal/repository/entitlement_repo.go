@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EntitlementRepository struct {
+	db *gorm.DB
+}
+
+func NewEntitlementRepository(db *gorm.DB) *EntitlementRepository {
+	return &EntitlementRepository{db: db}
+}
+
+func (r *EntitlementRepository) Migrate() error {
+	// Older rows stored an empty reference, which the unique index would
+	// treat as a value.
+	if r.db.Migrator().HasTable(&EntitlementModel{}) {
+		if err := r.db.Model(&EntitlementModel{}).Where("reference = ?", "").Update("reference", nil).Error; err != nil {
+			return err
+		}
+	}
+	return r.db.AutoMigrate(&EntitlementModel{})
+}
+
+// MAPPERS
+
+func entitlementModelToDomain(m *EntitlementModel) *domain.Entitlement {
+	e := &domain.Entitlement{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Tier:      m.Tier,
+		Source:    m.Source,
+		ExpiresAt: m.ExpiresAt,
+		CreatedAt: m.CreatedAt,
+	}
+	if m.Reference != nil {
+		e.Reference = *m.Reference
+	}
+	return e
+}
+
+// CRUD
+
+// Create stores an entitlement, or fails with domain.ErrEntitlementExists
+// when one was already issued for the same source and reference.
+func (r *EntitlementRepository) Create(e *domain.Entitlement) error {
+	m := &EntitlementModel{
+		UserID:    e.UserID,
+		Tier:      e.Tier,
+		Source:    e.Source,
+		ExpiresAt: e.ExpiresAt,
+	}
+	if e.Reference != "" {
+		m.Reference = &e.Reference
+	}
+	res := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(m)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrEntitlementExists
+	}
+	e.ID = m.ID
+	e.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (r *EntitlementRepository) FindByID(id uint) (*domain.Entitlement, error) {
+	var m EntitlementModel
+	if err := r.db.First(&m, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("entitlement not found")
+		}
+		return nil, err
+	}
+	return entitlementModelToDomain(&m), nil
+}
+
+func (r *EntitlementRepository) FindByUserID(userID uint) ([]*domain.Entitlement, error) {
+	var ms []EntitlementModel
+	if err := r.db.Where("user_id = ?", userID).Order("id").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.Entitlement, 0, len(ms))
+	for i := range ms {
+		out = append(out, entitlementModelToDomain(&ms[i]))
+	}
+	return out, nil
+}
+
+func (r *EntitlementRepository) Delete(id uint) error {
+	res := r.db.Delete(&EntitlementModel{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errors.New("entitlement not found")
+	}
+	return nil
+}
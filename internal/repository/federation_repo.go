@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FederationRepository stores the ActivityPub state of authors: their
+// actor keys, remote followers and the delivery outbox.
+type FederationRepository struct {
+	db *gorm.DB
+}
+
+func NewFederationRepository(db *gorm.DB) *FederationRepository {
+	return &FederationRepository{db: db}
+}
+
+func (r *FederationRepository) Migrate() error {
+	return r.db.AutoMigrate(&ActorKeyModel{}, &FollowerModel{}, &ActivityDeliveryModel{})
+}
+
+// MAPPERS
+
+func followerModelToDomain(m *FollowerModel) *domain.Follower {
+	return &domain.Follower{
+		ID:        m.ID,
+		AuthorID:  m.AuthorID,
+		ActorID:   m.ActorID,
+		Inbox:     m.Inbox,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func deliveryModelToDomain(m *ActivityDeliveryModel) *domain.Delivery {
+	d := &domain.Delivery{
+		ID:        m.ID,
+		Key:       m.Key,
+		AuthorID:  m.AuthorID,
+		Inbox:     m.Inbox,
+		Payload:   m.Payload,
+		Attempts:  m.Attempts,
+		LastError: m.LastError,
+	}
+	if m.NextAttemptAt != nil {
+		d.NextAttemptAt = *m.NextAttemptAt
+	}
+	return d
+}
+
+// KEYS
+
+// FindKey returns the author's actor key, or nil if none was created yet.
+func (r *FederationRepository) FindKey(authorID uint) (*domain.ActorKey, error) {
+	var m ActorKeyModel
+	if err := r.db.Where("author_id = ?", authorID).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &domain.ActorKey{AuthorID: m.AuthorID, PublicKeyPEM: m.PublicKeyPEM, PrivateKeyPEM: m.PrivateKeyPEM}, nil
+}
+
+// CreateKey stores the author's key unless another request stored one first.
+func (r *FederationRepository) CreateKey(k *domain.ActorKey) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&ActorKeyModel{
+		AuthorID:      k.AuthorID,
+		PublicKeyPEM:  k.PublicKeyPEM,
+		PrivateKeyPEM: k.PrivateKeyPEM,
+	}).Error
+}
+
+// FOLLOWERS
+
+// SaveFollower adds the follower, or updates its inbox if it already follows.
+func (r *FederationRepository) SaveFollower(f *domain.Follower) error {
+	m := &FollowerModel{AuthorID: f.AuthorID, ActorID: f.ActorID, Inbox: f.Inbox}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "author_id"}, {Name: "actor_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"inbox"}),
+	}).Create(m).Error
+	if err != nil {
+		return err
+	}
+	f.ID = m.ID
+	return nil
+}
+
+func (r *FederationRepository) DeleteFollower(authorID uint, actorID string) error {
+	return r.db.Where("author_id = ? AND actor_id = ?", authorID, actorID).Delete(&FollowerModel{}).Error
+}
+
+func (r *FederationRepository) FindFollowers(authorID uint) ([]*domain.Follower, error) {
+	var ms []FollowerModel
+	if err := r.db.Where("author_id = ?", authorID).Order("id").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.Follower, 0, len(ms))
+	for i := range ms {
+		out = append(out, followerModelToDomain(&ms[i]))
+	}
+	return out, nil
+}
+
+func (r *FederationRepository) CountFollowers(authorID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&FollowerModel{}).Where("author_id = ?", authorID).Count(&count).Error
+	return count, err
+}
+
+// DELIVERIES
+
+// EnqueueDeliveries adds deliveries to the outbox, skipping any whose key is
+// already there.
+func (r *FederationRepository) EnqueueDeliveries(ds ...*domain.Delivery) error {
+	if len(ds) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	ms := make([]ActivityDeliveryModel, 0, len(ds))
+	for _, d := range ds {
+		ms = append(ms, ActivityDeliveryModel{
+			Key:           d.Key,
+			AuthorID:      d.AuthorID,
+			Inbox:         d.Inbox,
+			Payload:       d.Payload,
+			NextAttemptAt: &now,
+		})
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&ms, 500).Error
+}
+
+// FindDueDeliveries returns up to limit deliveries whose next attempt is due.
+func (r *FederationRepository) FindDueDeliveries(now time.Time, limit int) ([]*domain.Delivery, error) {
+	var ms []ActivityDeliveryModel
+	if err := r.db.Where("next_attempt_at <= ?", now).Order("next_attempt_at").Limit(limit).Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.Delivery, 0, len(ms))
+	for i := range ms {
+		out = append(out, deliveryModelToDomain(&ms[i]))
+	}
+	return out, nil
+}
+
+// MarkDelivered takes a delivery out of the queue.
+func (r *FederationRepository) MarkDelivered(id uint) error {
+	return r.db.Model(&ActivityDeliveryModel{}).Where("id = ?", id).Update("next_attempt_at", nil).Error
+}
+
+// SaveAttempt records a failed attempt; retry false abandons the delivery.
+func (r *FederationRepository) SaveAttempt(d *domain.Delivery, retry bool) error {
+	var next *time.Time
+	if retry {
+		next = &d.NextAttemptAt
+	}
+	return r.db.Model(&ActivityDeliveryModel{}).Where("id = ?", d.ID).Updates(map[string]any{
+		"attempts":        d.Attempts,
+		"last_error":      d.LastError,
+		"next_attempt_at": next,
+	}).Error
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type ImportRecordRepository struct {
+	db *gorm.DB
+}
+
+func NewImportRecordRepository(db *gorm.DB) *ImportRecordRepository {
+	return &ImportRecordRepository{db: db}
+}
+
+func (r *ImportRecordRepository) Migrate() error {
+	return r.db.AutoMigrate(&ImportRecordModel{})
+}
+
+// Find returns the local ID recorded for an external object, if any.
+func (r *ImportRecordRepository) Find(source, kind, externalID string) (uint, bool, error) {
+	var m ImportRecordModel
+	err := r.db.Where("source = ? AND kind = ? AND external_id = ?", source, kind, externalID).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return m.LocalID, true, nil
+}
+
+func (r *ImportRecordRepository) Save(source, kind, externalID string, localID uint) error {
+	return r.db.Create(&ImportRecordModel{
+		Source:     source,
+		Kind:       kind,
+		ExternalID: externalID,
+		LocalID:    localID,
+	}).Error
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MediaRepository struct {
+	db *gorm.DB
+}
+
+func NewMediaRepository(db *gorm.DB) *MediaRepository {
+	return &MediaRepository{db: db}
+}
+
+func (r *MediaRepository) Migrate() error {
+	return r.db.AutoMigrate(&MediaModel{})
+}
+
+// MAPPERS
+
+func mediaModelToDomain(m *MediaModel) *domain.Media {
+	return &domain.Media{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		Name:        m.Name,
+		ContentType: m.ContentType,
+		Data:        m.Data,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+// CRUD
+
+// CreateWithinQuota stores a file unless it would take the uploader's files
+// past quota bytes, failing with domain.ErrMediaQuotaExceeded. The uploader's
+// user row stays locked until the file is stored, so concurrent uploads
+// can't each pass the check.
+func (r *MediaRepository) CreateWithinQuota(media *domain.Media, quota int64) error {
+	m := MediaModel{
+		UserID:      media.UserID,
+		Name:        media.Name,
+		ContentType: media.ContentType,
+		Data:        media.Data,
+		Size:        int64(len(media.Data)),
+		CreatedAt:   media.CreatedAt,
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&UserModel{}, media.UserID).Error; err != nil {
+			return err
+		}
+		used, err := usedBytes(tx, media.UserID)
+		if err != nil {
+			return err
+		}
+		if used+m.Size > quota {
+			return domain.ErrMediaQuotaExceeded
+		}
+		return tx.Create(&m).Error
+	})
+	if err != nil {
+		return err
+	}
+	media.ID = m.ID
+	return nil
+}
+
+func (r *MediaRepository) FindByID(id uint) (*domain.Media, error) {
+	var m MediaModel
+	err := r.db.First(&m, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrMediaNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mediaModelToDomain(&m), nil
+}
+
+// usedBytes is the total size of the files userID uploaded.
+func usedBytes(db *gorm.DB, userID uint) (int64, error) {
+	var total int64
+	err := db.Model(&MediaModel{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(SUM(size), 0)").
+		Scan(&total).Error
+	return total, err
+}
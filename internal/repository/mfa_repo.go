@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"gorm.io/gorm"
+)
+
+type MFARepository struct {
+	db *gorm.DB
+}
+
+func NewMFARepository(db *gorm.DB) *MFARepository {
+	return &MFARepository{db: db}
+}
+
+func (r *MFARepository) Migrate() error {
+	return r.db.AutoMigrate(&TOTPModel{}, &RecoveryCodeModel{})
+}
+
+// CRUD
+
+// FindTOTP returns the user's enrollment, nil if there is none.
+func (r *MFARepository) FindTOTP(userID uint) (*domain.TOTPEnrollment, error) {
+	var m TOTPModel
+	if err := r.db.First(&m, "user_id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &domain.TOTPEnrollment{UserID: m.UserID, Secret: m.Secret, Enabled: m.Enabled}, nil
+}
+
+// SavePendingTOTP starts an enrollment, replacing a pending one.
+func (r *MFARepository) SavePendingTOTP(e *domain.TOTPEnrollment) error {
+	return r.db.Save(&TOTPModel{UserID: e.UserID, Secret: e.Secret}).Error
+}
+
+// EnableTOTP confirms the enrollment with the code of step and replaces the
+// user's recovery codes.
+func (r *MFARepository) EnableTOTP(userID uint, step int64, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&TOTPModel{}).Where("user_id = ?", userID).Updates(map[string]any{
+			"enabled":   true,
+			"last_step": step,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&RecoveryCodeModel{}).Error; err != nil {
+			return err
+		}
+		codes := make([]RecoveryCodeModel, len(codeHashes))
+		for i, hash := range codeHashes {
+			codes[i] = RecoveryCodeModel{UserID: userID, CodeHash: hash}
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// DeleteTOTP removes the user's enrollment and recovery codes.
+func (r *MFARepository) DeleteTOTP(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&RecoveryCodeModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&TOTPModel{}).Error
+	})
+}
+
+// UseTOTPStep records that the code of step was used. It reports false if a
+// code of that step or a later one was used already.
+func (r *MFARepository) UseTOTPStep(userID uint, step int64) (bool, error) {
+	res := r.db.Model(&TOTPModel{}).
+		Where("user_id = ? AND last_step < ?", userID, step).
+		Update("last_step", step)
+	return res.RowsAffected == 1, res.Error
+}
+
+// UseRecoveryCode marks an unused recovery code of the user as used. It
+// reports false if there is none with the hash.
+func (r *MFARepository) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	res := r.db.Model(&RecoveryCodeModel{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return res.RowsAffected == 1, res.Error
+}
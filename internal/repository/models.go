@@ -8,6 +8,33 @@ type UserModel struct {
 	Password string `gorm:"not null"`
 	Role     string `gorm:"not null"` // User // Author
 
+	EmailVerified bool `gorm:"not null;default:false"`
+	Banned        bool `gorm:"not null;default:false"`
+
+	DisplayName   string
+	Bio           string `gorm:"type:text"`
+	AvatarMediaID uint
+	Website       string
+}
+
+// UserRoleModel grants a user a staff role.
+type UserRoleModel struct {
+	UserID    uint   `gorm:"primarykey"`
+	Role      string `gorm:"primarykey"`
+	CreatedAt time.Time
+}
+
+// EntitlementModel is a granted tier. Reference is NULL rather than empty
+// when unset, so that the unique index only binds entitlements issued for
+// something, such as a payment, which can then be granted only once.
+type EntitlementModel struct {
+	ID        uint    `gorm:"primarykey;autoIncrement"`
+	UserID    uint    `gorm:"not null;index"`
+	Tier      string  `gorm:"not null"`
+	Source    string  `gorm:"not null;uniqueIndex:idx_entitlement_reference"`
+	Reference *string `gorm:"uniqueIndex:idx_entitlement_reference"`
+	ExpiresAt *time.Time
+	CreatedAt time.Time
 }
 
 type AuthorModel struct {
@@ -16,12 +43,51 @@ type AuthorModel struct {
 }
 
 type BlogModel struct {
-	ID        uint   `gorm:"primarykey;autoIncrement"`
-	AuthorID  uint   `gorm:"not null"`
-	Title     string `gorm:"not null"`
-	Content   string `gorm:"type:text"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID            uint   `gorm:"primarykey;autoIncrement"`
+	PublicationID uint   `gorm:"not null;default:0;index"` // 0 = default publication
+	AuthorID      uint   `gorm:"not null;index"`
+	Slug          string `gorm:"index"`
+	Locale        string
+	Title         string `gorm:"not null"`
+	Content       string `gorm:"type:text"`
+	Tags          string `gorm:"type:text"` // comma separated
+	Categories    string `gorm:"type:text"` // comma separated
+	Status        string `gorm:"not null;default:PUBLISHED"`
+	Visibility    string `gorm:"not null;default:PUBLIC"`
+	// RequiredTier paywalls the content after the marker, empty for free posts
+	RequiredTier string
+	// SEO settings, empty when derived from the content
+	MetaDescription string
+	CanonicalURL    string
+	SocialImage     string
+	NoIndex         bool
+	PublishedAt     *time.Time
+	Version         uint `gorm:"not null;default:1"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// PublicationModel is a team blog. CustomDomain is NULL rather than empty
+// when unset, so that its unique index allows many publications without one.
+type PublicationModel struct {
+	ID            uint    `gorm:"primarykey;autoIncrement"`
+	Slug          string  `gorm:"not null;uniqueIndex"`
+	Name          string  `gorm:"not null"`
+	Description   string  `gorm:"type:text"`
+	CustomDomain  *string `gorm:"uniqueIndex"`
+	PendingDomain string
+	DomainToken   string
+	DefaultLocale string
+	RequireReview bool `gorm:"not null;default:false"`
+	CreatedAt     time.Time
+}
+
+type PublicationMemberModel struct {
+	ID            uint   `gorm:"primarykey;autoIncrement"`
+	PublicationID uint   `gorm:"not null;uniqueIndex:idx_publication_member"`
+	UserID        uint   `gorm:"not null;uniqueIndex:idx_publication_member;index"`
+	Role          string `gorm:"not null"`
+	CreatedAt     time.Time
 }
 
 type NotificationModel struct {
@@ -30,3 +96,183 @@ type NotificationModel struct {
 	Message string `gorm:"type:text"`
 	Sent    bool
 }
+
+type PostTranslationModel struct {
+	ID        uint   `gorm:"primarykey;autoIncrement"`
+	BlogID    uint   `gorm:"not null;uniqueIndex:idx_post_locale"`
+	Locale    string `gorm:"not null;uniqueIndex:idx_post_locale"`
+	Title     string `gorm:"not null"`
+	Content   string `gorm:"type:text"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// PostEventModel is the durable log of published post events. Its ID is the
+// event sequence number used as a resume token.
+type PostEventModel struct {
+	ID            uint64 `gorm:"primarykey;autoIncrement"`
+	Type          string `gorm:"not null"`
+	PublicationID uint   `gorm:"not null;default:0"`
+	PostID        uint   `gorm:"not null;index"`
+	AuthorID      uint   `gorm:"not null"`
+	Payload       string `gorm:"type:text"` // JSON encoded domain.PostEvent
+	CreatedAt     time.Time
+}
+
+type ReviewCommentModel struct {
+	ID           uint   `gorm:"primarykey;autoIncrement"`
+	BlogID       uint   `gorm:"not null;index"`
+	EditorUserID uint   `gorm:"not null"`
+	Quote        string `gorm:"type:text"`
+	Offset       int
+	Body         string `gorm:"type:text;not null"`
+	CreatedAt    time.Time
+}
+
+type CommentModel struct {
+	ID          uint `gorm:"primarykey;autoIncrement"`
+	BlogID      uint `gorm:"not null;index"`
+	ParentID    uint
+	UserID      uint
+	AuthorName  string
+	AuthorEmail string
+	AuthorURL   string
+	Content     string `gorm:"type:text"`
+	Status      string `gorm:"not null"`
+	CreatedAt   time.Time
+}
+
+// PostAutosaveModel holds the latest autosave of one user's editor for one
+// post; PostID 0 is a post that was not created yet.
+type PostAutosaveModel struct {
+	ID        uint   `gorm:"primarykey;autoIncrement"`
+	UserID    uint   `gorm:"not null;uniqueIndex:idx_autosave_user_post"`
+	PostID    uint   `gorm:"not null;uniqueIndex:idx_autosave_user_post"`
+	Title     string `gorm:"not null"`
+	Content   string `gorm:"type:text"`
+	Hash      string `gorm:"not null"`
+	UpdatedAt time.Time
+}
+
+type SubscriberModel struct {
+	ID             uint   `gorm:"primarykey;autoIncrement"`
+	Email          string `gorm:"not null;uniqueIndex:idx_subscriber_scope"`
+	AuthorID       uint   `gorm:"not null;uniqueIndex:idx_subscriber_scope"` // 0 = all posts
+	Confirmed      bool   `gorm:"not null;default:false"`
+	TokenHash      string `gorm:"index"`
+	TokenExpiresAt *time.Time
+	CreatedAt      time.Time
+	ConfirmedAt    *time.Time
+}
+
+type OutboundEmailModel struct {
+	ID              uint   `gorm:"primarykey;autoIncrement"`
+	Key             string `gorm:"not null;uniqueIndex"`
+	To              string `gorm:"not null"`
+	Subject         string `gorm:"not null"`
+	Body            string `gorm:"type:text"`
+	ListUnsubscribe string
+	Sent            bool `gorm:"not null;default:false;index"`
+	CreatedAt       time.Time
+}
+
+type ActorKeyModel struct {
+	ID            uint   `gorm:"primarykey;autoIncrement"`
+	AuthorID      uint   `gorm:"not null;uniqueIndex"`
+	PublicKeyPEM  string `gorm:"type:text;not null"`
+	PrivateKeyPEM string `gorm:"type:text;not null"`
+	CreatedAt     time.Time
+}
+
+type FollowerModel struct {
+	ID        uint   `gorm:"primarykey;autoIncrement"`
+	AuthorID  uint   `gorm:"not null;uniqueIndex:idx_follower"`
+	ActorID   string `gorm:"not null;uniqueIndex:idx_follower"`
+	Inbox     string `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+// ActivityDeliveryModel is the outbox of activities to POST to remote
+// inboxes. Delivered and abandoned rows have a NULL NextAttemptAt.
+type ActivityDeliveryModel struct {
+	ID            uint       `gorm:"primarykey;autoIncrement"`
+	Key           string     `gorm:"not null;uniqueIndex"`
+	AuthorID      uint       `gorm:"not null"`
+	Inbox         string     `gorm:"not null"`
+	Payload       string     `gorm:"type:text;not null"`
+	Attempts      int        `gorm:"not null;default:0"`
+	NextAttemptAt *time.Time `gorm:"index"`
+	LastError     string
+	CreatedAt     time.Time
+}
+
+// OutgoingWebmentionModel queues the webmentions to send for a post's
+// links. Sent and abandoned rows have a NULL NextAttemptAt.
+type OutgoingWebmentionModel struct {
+	ID            uint       `gorm:"primarykey;autoIncrement"`
+	PostID        uint       `gorm:"not null;index"`
+	Source        string     `gorm:"not null;uniqueIndex:idx_outgoing_webmention"`
+	Target        string     `gorm:"not null;uniqueIndex:idx_outgoing_webmention"`
+	Attempts      int        `gorm:"not null;default:0"`
+	NextAttemptAt *time.Time `gorm:"index"`
+	LastError     string
+	CreatedAt     time.Time
+}
+
+// IncomingWebmentionModel is a received webmention; NextAttemptAt is set
+// while its source still has to be verified.
+type IncomingWebmentionModel struct {
+	ID            uint   `gorm:"primarykey;autoIncrement"`
+	Source        string `gorm:"not null;uniqueIndex:idx_incoming_webmention"`
+	Target        string `gorm:"not null"`
+	BlogID        uint   `gorm:"not null;uniqueIndex:idx_incoming_webmention"`
+	CommentID     uint
+	Status        string     `gorm:"not null"`
+	Attempts      int        `gorm:"not null;default:0"`
+	NextAttemptAt *time.Time `gorm:"index"`
+	LastError     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// MediaModel stores an uploaded file.
+type MediaModel struct {
+	ID          uint   `gorm:"primarykey;autoIncrement"`
+	UserID      uint   `gorm:"not null;index"`
+	Name        string `gorm:"not null"`
+	ContentType string `gorm:"not null"`
+	Data        []byte `gorm:"not null"`
+	Size        int64  `gorm:"not null;default:0"`
+	CreatedAt   time.Time
+}
+
+// ImportRecordModel remembers which external objects an importer already
+// created, so an interrupted import can be resumed without duplicates.
+type ImportRecordModel struct {
+	ID         uint   `gorm:"primarykey;autoIncrement"`
+	Source     string `gorm:"not null;uniqueIndex:idx_import_record"`
+	Kind       string `gorm:"not null;uniqueIndex:idx_import_record"`
+	ExternalID string `gorm:"not null;uniqueIndex:idx_import_record"`
+	LocalID    uint   `gorm:"not null"`
+	CreatedAt  time.Time
+}
+
+// TOTPModel is a user's authenticator enrollment. LastStep is the time step
+// of the last accepted code, which can't be used again.
+type TOTPModel struct {
+	UserID    uint   `gorm:"primarykey"`
+	Secret    string `gorm:"not null"`
+	Enabled   bool   `gorm:"not null;default:false"`
+	LastStep  int64  `gorm:"not null;default:0"`
+	CreatedAt time.Time
+}
+
+// RecoveryCodeModel is a hashed one-time recovery code for a user's second
+// factor.
+type RecoveryCodeModel struct {
+	ID        uint   `gorm:"primarykey;autoIncrement"`
+	UserID    uint   `gorm:"not null;index"`
+	CodeHash  string `gorm:"not null;uniqueIndex"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"gorm.io/gorm"
+)
+
+type PublicationRepository struct {
+	db *gorm.DB
+}
+
+func NewPublicationRepository(db *gorm.DB) *PublicationRepository {
+	return &PublicationRepository{db: db}
+}
+
+func (r *PublicationRepository) Migrate() error {
+	return r.db.AutoMigrate(&PublicationModel{}, &PublicationMemberModel{})
+}
+
+// MAPPERS
+
+func publicationModelToDomain(m *PublicationModel) *domain.Publication {
+	p := &domain.Publication{
+		ID:            m.ID,
+		Slug:          m.Slug,
+		Name:          m.Name,
+		Description:   m.Description,
+		PendingDomain: m.PendingDomain,
+		DomainToken:   m.DomainToken,
+		Settings: domain.PublicationSettings{
+			DefaultLocale: m.DefaultLocale,
+			RequireReview: m.RequireReview,
+		},
+		CreatedAt: m.CreatedAt,
+	}
+	if m.CustomDomain != nil {
+		p.CustomDomain = *m.CustomDomain
+	}
+	return p
+}
+
+func publicationDomainToModel(p *domain.Publication) *PublicationModel {
+	m := &PublicationModel{
+		ID:            p.ID,
+		Slug:          p.Slug,
+		Name:          p.Name,
+		Description:   p.Description,
+		PendingDomain: p.PendingDomain,
+		DomainToken:   p.DomainToken,
+		DefaultLocale: p.Settings.DefaultLocale,
+		RequireReview: p.Settings.RequireReview,
+		CreatedAt:     p.CreatedAt,
+	}
+	if p.CustomDomain != "" {
+		m.CustomDomain = &p.CustomDomain
+	}
+	return m
+}
+
+func memberModelToDomain(m *PublicationMemberModel) *domain.PublicationMember {
+	return &domain.PublicationMember{
+		PublicationID: m.PublicationID,
+		UserID:        m.UserID,
+		Role:          m.Role,
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+// CRUD
+
+// Create stores the publication with its first member, its owner.
+func (r *PublicationRepository) Create(p *domain.Publication, ownerUserID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		m := publicationDomainToModel(p)
+		if err := tx.Create(m).Error; err != nil {
+			return err
+		}
+		p.ID = m.ID
+
+		return tx.Create(&PublicationMemberModel{
+			PublicationID: m.ID,
+			UserID:        ownerUserID,
+			Role:          domain.PublicationOwner,
+		}).Error
+	})
+}
+
+func (r *PublicationRepository) FindByID(id uint) (*domain.Publication, error) {
+	var m PublicationModel
+	if err := r.db.First(&m, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPublicationNotFound
+		}
+		return nil, err
+	}
+	return publicationModelToDomain(&m), nil
+}
+
+func (r *PublicationRepository) FindByDomain(host string) (*domain.Publication, error) {
+	var m PublicationModel
+	if err := r.db.Where("custom_domain = ?", host).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPublicationNotFound
+		}
+		return nil, err
+	}
+	return publicationModelToDomain(&m), nil
+}
+
+// DomainOverlaps reports whether a publication other than exceptID serves
+// host, a subdomain of it or one of its parent domains.
+func (r *PublicationRepository) DomainOverlaps(host string, exceptID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&PublicationModel{}).
+		Where("id <> ? AND (custom_domain = ? OR custom_domain LIKE ? OR ? LIKE '%.' || custom_domain)",
+			exceptID, host, "%."+host, host).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *PublicationRepository) SlugExists(slug string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&PublicationModel{}).Where("slug = ?", slug).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *PublicationRepository) Update(p *domain.Publication) error {
+	m := publicationDomainToModel(p)
+	return r.db.Model(&PublicationModel{}).Where("id = ?", p.ID).Updates(map[string]any{
+		"name":           m.Name,
+		"description":    m.Description,
+		"custom_domain":  m.CustomDomain,
+		"pending_domain": m.PendingDomain,
+		"domain_token":   m.DomainToken,
+		"default_locale": m.DefaultLocale,
+		"require_review": m.RequireReview,
+	}).Error
+}
+
+// MEMBERS
+
+func (r *PublicationRepository) FindMember(publicationID, userID uint) (*domain.PublicationMember, error) {
+	var m PublicationMemberModel
+	err := r.db.Where("publication_id = ? AND user_id = ?", publicationID, userID).First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotMember
+		}
+		return nil, err
+	}
+	return memberModelToDomain(&m), nil
+}
+
+func (r *PublicationRepository) ListMembers(publicationID uint) ([]*domain.PublicationMember, error) {
+	var ms []PublicationMemberModel
+	if err := r.db.Where("publication_id = ?", publicationID).Order("id").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.PublicationMember, 0, len(ms))
+	for i := range ms {
+		out = append(out, memberModelToDomain(&ms[i]))
+	}
+	return out, nil
+}
+
+// SaveMember adds the member or changes their role.
+func (r *PublicationRepository) SaveMember(member *domain.PublicationMember) error {
+	var m PublicationMemberModel
+	err := r.db.Where("publication_id = ? AND user_id = ?", member.PublicationID, member.UserID).First(&m).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	m.PublicationID = member.PublicationID
+	m.UserID = member.UserID
+	m.Role = member.Role
+	if err := r.db.Save(&m).Error; err != nil {
+		return err
+	}
+	member.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (r *PublicationRepository) DeleteMember(publicationID, userID uint) error {
+	res := r.db.Where("publication_id = ? AND user_id = ?", publicationID, userID).Delete(&PublicationMemberModel{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrNotMember
+	}
+	return nil
+}
+
+func (r *PublicationRepository) CountOwners(publicationID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&PublicationMemberModel{}).
+		Where("publication_id = ? AND role = ?", publicationID, domain.PublicationOwner).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"gorm.io/gorm"
+)
+
+type SubscriberRepository struct {
+	db *gorm.DB
+}
+
+func NewSubscriberRepository(db *gorm.DB) *SubscriberRepository {
+	return &SubscriberRepository{db: db}
+}
+
+func (r *SubscriberRepository) Migrate() error {
+	return r.db.AutoMigrate(&SubscriberModel{})
+}
+
+// MAPPERS
+
+func subscriberModelToDomain(m *SubscriberModel) *domain.Subscriber {
+	return &domain.Subscriber{
+		ID:             m.ID,
+		Email:          m.Email,
+		AuthorID:       m.AuthorID,
+		Confirmed:      m.Confirmed,
+		TokenHash:      m.TokenHash,
+		TokenExpiresAt: m.TokenExpiresAt,
+		CreatedAt:      m.CreatedAt,
+		ConfirmedAt:    m.ConfirmedAt,
+	}
+}
+
+func subscriberDomainToModel(s *domain.Subscriber) *SubscriberModel {
+	return &SubscriberModel{
+		ID:             s.ID,
+		Email:          s.Email,
+		AuthorID:       s.AuthorID,
+		Confirmed:      s.Confirmed,
+		TokenHash:      s.TokenHash,
+		TokenExpiresAt: s.TokenExpiresAt,
+		CreatedAt:      s.CreatedAt,
+		ConfirmedAt:    s.ConfirmedAt,
+	}
+}
+
+// CRUD
+
+// Save creates or updates a subscription.
+func (r *SubscriberRepository) Save(s *domain.Subscriber) error {
+	m := subscriberDomainToModel(s)
+	if err := r.db.Save(m).Error; err != nil {
+		return err
+	}
+	s.ID = m.ID
+	return nil
+}
+
+// Find returns the subscription of email to a scope, or nil.
+func (r *SubscriberRepository) Find(email string, authorID uint) (*domain.Subscriber, error) {
+	var m SubscriberModel
+	err := r.db.Where("email = ? AND author_id = ?", email, authorID).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return subscriberModelToDomain(&m), nil
+}
+
+func (r *SubscriberRepository) FindByTokenHash(hash string) (*domain.Subscriber, error) {
+	var m SubscriberModel
+	err := r.db.Where("token_hash = ? AND token_hash <> ''", hash).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrInvalidConfirmToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return subscriberModelToDomain(&m), nil
+}
+
+// FindConfirmedFor returns the confirmed subscriptions that cover a post by
+// the author: all-posts subscriptions and those to that author.
+func (r *SubscriberRepository) FindConfirmedFor(authorID uint) ([]*domain.Subscriber, error) {
+	var ms []SubscriberModel
+	err := r.db.Where("confirmed = ? AND author_id IN ?", true, []uint{0, authorID}).
+		Order("id").Find(&ms).Error
+	if err != nil {
+		return nil, err
+	}
+
+	subs := make([]*domain.Subscriber, 0, len(ms))
+	for i := range ms {
+		subs = append(subs, subscriberModelToDomain(&ms[i]))
+	}
+	return subs, nil
+}
+
+func (r *SubscriberRepository) Delete(id uint) error {
+	res := r.db.Delete(&SubscriberModel{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrSubscriptionNotFound
+	}
+	return nil
+}
@@ -16,7 +16,17 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 }
 
 func (r *UserRepository) Migrate() error {
-	return r.db.AutoMigrate(&UserModel{})
+	// Accounts from before email verification existed count as verified.
+	grandfather := r.db.Migrator().HasTable(&UserModel{}) &&
+		!r.db.Migrator().HasColumn(&UserModel{}, "email_verified")
+
+	if err := r.db.AutoMigrate(&UserModel{}, &UserRoleModel{}); err != nil {
+		return err
+	}
+	if grandfather {
+		return r.db.Model(&UserModel{}).Where("1 = 1").Update("email_verified", true).Error
+	}
+	return nil
 }
 
 // Mapper //DB ---> Domain
@@ -26,6 +36,15 @@ func toDomainUser(m *UserModel) *domain.User {
 		Email:    m.Email,
 		Password: m.Password,
 		Role:     m.Role,
+
+		EmailVerified: m.EmailVerified,
+		Banned:        m.Banned,
+		Profile: domain.UserProfile{
+			DisplayName:   m.DisplayName,
+			Bio:           m.Bio,
+			AvatarMediaID: m.AvatarMediaID,
+			Website:       m.Website,
+		},
 	}
 }
 
@@ -36,6 +55,8 @@ func toModelUser(u *domain.User, password string) *UserModel {
 		Email:    u.Email,
 		Role:     u.Role,
 		Password: password,
+
+		EmailVerified: u.EmailVerified,
 	}
 }
 
@@ -53,7 +74,7 @@ func (r *UserRepository) FindByID(id uint) (*domain.User, error) {
 	var m UserModel // local user model declare
 	if err := r.db.First(&m, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, domain.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -64,7 +85,7 @@ func (r *UserRepository) FindByEmail(email string) (*domain.User, error) {
 	var m UserModel
 	if err := r.db.Where("email=?", email).First(&m).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, domain.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -77,3 +98,48 @@ func (r *UserRepository) Update(u *domain.User) error {
 		"role":  u.Role,
 	}).Error
 }
+
+// FindRoles returns the staff roles granted to the user.
+func (r *UserRepository) FindRoles(userID uint) ([]string, error) {
+	var roles []string
+	err := r.db.Model(&UserRoleModel{}).Where("user_id = ?", userID).Order("role").Pluck("role", &roles).Error
+	return roles, err
+}
+
+// SetRoles replaces the staff roles granted to the user.
+func (r *UserRepository) SetRoles(userID uint, roles []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&UserRoleModel{}).Error; err != nil {
+			return err
+		}
+		if len(roles) == 0 {
+			return nil
+		}
+		models := make([]UserRoleModel, len(roles))
+		for i, role := range roles {
+			models[i] = UserRoleModel{UserID: userID, Role: role}
+		}
+		return tx.Create(&models).Error
+	})
+}
+
+func (r *UserRepository) SetBanned(id uint, banned bool) error {
+	return r.db.Model(&UserModel{}).Where("id = ?", id).Update("banned", banned).Error
+}
+
+func (r *UserRepository) MarkEmailVerified(id uint) error {
+	return r.db.Model(&UserModel{}).Where("id = ?", id).Update("email_verified", true).Error
+}
+
+func (r *UserRepository) UpdatePassword(id uint, hash string) error {
+	return r.db.Model(&UserModel{}).Where("id = ?", id).Update("password", hash).Error
+}
+
+func (r *UserRepository) UpdateProfile(u *domain.User) error {
+	return r.db.Model(&UserModel{}).Where("id = ?", u.ID).Updates(map[string]any{
+		"display_name":    u.Profile.DisplayName,
+		"bio":             u.Profile.Bio,
+		"avatar_media_id": u.Profile.AvatarMediaID,
+		"website":         u.Profile.Website,
+	}).Error
+}
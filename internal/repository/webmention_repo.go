@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebmentionRepository struct {
+	db *gorm.DB
+}
+
+func NewWebmentionRepository(db *gorm.DB) *WebmentionRepository {
+	return &WebmentionRepository{db: db}
+}
+
+func (r *WebmentionRepository) Migrate() error {
+	return r.db.AutoMigrate(&OutgoingWebmentionModel{}, &IncomingWebmentionModel{})
+}
+
+// MAPPERS
+
+func outgoingWebmentionModelToDomain(m *OutgoingWebmentionModel) *domain.OutgoingWebmention {
+	w := &domain.OutgoingWebmention{
+		ID:        m.ID,
+		PostID:    m.PostID,
+		Source:    m.Source,
+		Target:    m.Target,
+		Attempts:  m.Attempts,
+		LastError: m.LastError,
+	}
+	if m.NextAttemptAt != nil {
+		w.NextAttemptAt = *m.NextAttemptAt
+	}
+	return w
+}
+
+func incomingWebmentionModelToDomain(m *IncomingWebmentionModel) *domain.IncomingWebmention {
+	w := &domain.IncomingWebmention{
+		ID:        m.ID,
+		Source:    m.Source,
+		Target:    m.Target,
+		BlogID:    m.BlogID,
+		CommentID: m.CommentID,
+		Status:    m.Status,
+		Attempts:  m.Attempts,
+		LastError: m.LastError,
+	}
+	if m.NextAttemptAt != nil {
+		w.NextAttemptAt = *m.NextAttemptAt
+	}
+	return w
+}
+
+// OUTGOING
+
+// EnqueueOutgoing queues webmentions to send, skipping (source, target)
+// pairs that were already queued.
+func (r *WebmentionRepository) EnqueueOutgoing(ws ...*domain.OutgoingWebmention) error {
+	if len(ws) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	ms := make([]OutgoingWebmentionModel, 0, len(ws))
+	for _, w := range ws {
+		ms = append(ms, OutgoingWebmentionModel{
+			PostID:        w.PostID,
+			Source:        w.Source,
+			Target:        w.Target,
+			NextAttemptAt: &now,
+		})
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&ms).Error
+}
+
+// FindDueOutgoing returns up to limit webmentions whose next attempt is due.
+func (r *WebmentionRepository) FindDueOutgoing(now time.Time, limit int) ([]*domain.OutgoingWebmention, error) {
+	var ms []OutgoingWebmentionModel
+	if err := r.db.Where("next_attempt_at <= ?", now).Order("next_attempt_at").Limit(limit).Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.OutgoingWebmention, 0, len(ms))
+	for i := range ms {
+		out = append(out, outgoingWebmentionModelToDomain(&ms[i]))
+	}
+	return out, nil
+}
+
+// SaveOutgoing records an attempt; retry false takes the webmention out of
+// the queue, sent or abandoned.
+func (r *WebmentionRepository) SaveOutgoing(w *domain.OutgoingWebmention, retry bool) error {
+	var next *time.Time
+	if retry {
+		next = &w.NextAttemptAt
+	}
+	return r.db.Model(&OutgoingWebmentionModel{}).Where("id = ?", w.ID).Updates(map[string]any{
+		"attempts":        w.Attempts,
+		"last_error":      w.LastError,
+		"next_attempt_at": next,
+	}).Error
+}
+
+// INCOMING
+
+// SaveReceived records a received webmention for verification. A source
+// that mentions the post again is verified again.
+func (r *WebmentionRepository) SaveReceived(w *domain.IncomingWebmention) error {
+	var m IncomingWebmentionModel
+	err := r.db.Where("source = ? AND blog_id = ?", w.Source, w.BlogID).First(&m).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	now := time.Now()
+	m.Source = w.Source
+	m.Target = w.Target
+	m.BlogID = w.BlogID
+	m.Status = domain.WebmentionPending
+	m.Attempts = 0
+	m.LastError = ""
+	m.NextAttemptAt = &now
+	if err := r.db.Save(&m).Error; err != nil {
+		return err
+	}
+	w.ID = m.ID
+	w.CommentID = m.CommentID
+	w.Status = m.Status
+	return nil
+}
+
+// FindDueIncoming returns up to limit received webmentions due for verification.
+func (r *WebmentionRepository) FindDueIncoming(now time.Time, limit int) ([]*domain.IncomingWebmention, error) {
+	var ms []IncomingWebmentionModel
+	if err := r.db.Where("next_attempt_at <= ?", now).Order("next_attempt_at").Limit(limit).Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.IncomingWebmention, 0, len(ms))
+	for i := range ms {
+		out = append(out, incomingWebmentionModelToDomain(&ms[i]))
+	}
+	return out, nil
+}
+
+// SaveIncoming records the outcome of a verification; retry keeps it queued.
+func (r *WebmentionRepository) SaveIncoming(w *domain.IncomingWebmention, retry bool) error {
+	var next *time.Time
+	if retry {
+		next = &w.NextAttemptAt
+	}
+	return r.db.Model(&IncomingWebmentionModel{}).Where("id = ?", w.ID).Updates(map[string]any{
+		"status":          w.Status,
+		"comment_id":      w.CommentID,
+		"attempts":        w.Attempts,
+		"last_error":      w.LastError,
+		"next_attempt_at": next,
+	}).Error
+}
@@ -1,8 +1,6 @@
 package usecase
 
 import (
-	"errors"
-
 	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
 )
@@ -22,21 +20,36 @@ func NewAuthorUsecase(
 	}
 }
 
-func (a *AuthorUsecase) BecomeAuthor(userID uint) error {
+// BecomeAuthor promotes the user to author. A non-empty name becomes their
+// profile's display name.
+func (a *AuthorUsecase) BecomeAuthor(userID uint, name string) error {
 	user, err := a.userRepo.FindByID(userID)
 	if err != nil {
 		return err
 	}
 
 	if user.Role == domain.RoleAuthor {
-		return errors.New("already author")
+		return domain.ErrAlreadyAuthor
+	}
+	if !user.EmailVerified {
+		return domain.ErrEmailNotVerified
 	}
 
+	if name != "" {
+		if err := (domain.ProfileChanges{DisplayName: &name}).Apply(&user.Profile); err != nil {
+			return err
+		}
+	}
 	user.PromoteToAuthor()
 
 	if err := a.userRepo.Update(user); err != nil {
 		return err
 	}
+	if name != "" {
+		if err := a.userRepo.UpdateProfile(user); err != nil {
+			return err
+		}
+	}
 
 	author := domain.NewAuthor(user.ID)
 	_, err = a.authorRepo.Create(author)
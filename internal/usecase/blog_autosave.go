@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+)
+
+// Autosave stores the viewer's unsaved title and content for a post they may
+// edit, or for a new post when postID is 0. It reports false when the content
+// is the same as the last autosave, and fails with ErrAutosaveThrottled when
+// called again within the autosave interval.
+func (b *BlogUsecase) Autosave(viewer domain.Viewer, postID uint, title, content string) (*domain.Autosave, bool, error) {
+	if err := b.checkAutosave(viewer, postID); err != nil {
+		return nil, false, err
+	}
+	userID := viewer.UserID
+
+	last, err := b.autosaves.Find(userID, postID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hash := domain.ContentHash(title, content)
+	if last != nil {
+		if last.Hash == hash {
+			return last, false, nil
+		}
+		if time.Since(last.SavedAt) < time.Duration(b.cfg.AutosaveSec)*time.Second {
+			return nil, false, domain.ErrAutosaveThrottled
+		}
+	}
+
+	a := &domain.Autosave{UserID: userID, PostID: postID, Title: title, Content: content, Hash: hash}
+	if err := b.autosaves.Save(a); err != nil {
+		return nil, false, err
+	}
+	return a, true, nil
+}
+
+// RestorableAutosave returns the autosave the editor should offer to
+// restore, or nil when there is none or it matches the saved post.
+func (b *BlogUsecase) RestorableAutosave(viewer domain.Viewer, postID uint) (*domain.Autosave, error) {
+	a, err := b.autosaves.Find(viewer.UserID, postID)
+	if err != nil || a == nil || postID == 0 {
+		return a, err
+	}
+
+	post, err := b.editablePost(viewer, postID)
+	if err != nil {
+		return nil, err
+	}
+	if domain.ContentHash(post.Title, post.Content) == a.Hash {
+		return nil, nil
+	}
+	return a, nil
+}
+
+// checkAutosave allows autosaving posts the viewer may edit, and new posts
+// when they may create posts.
+func (b *BlogUsecase) checkAutosave(viewer domain.Viewer, postID uint) error {
+	if postID != 0 {
+		_, err := b.editablePost(viewer, postID)
+		return err
+	}
+	if !b.resolveViewer(viewer).Can(domain.PermPostCreate) {
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
+
+// DiscardAutosave drops the author's autosave for a post.
+func (b *BlogUsecase) DiscardAutosave(userID, postID uint) error {
+	return b.autosaves.Delete(userID, postID)
+}
+
+// clearAutosave drops an autosave made obsolete by a save. Failing to do so
+// is harmless: RestorableAutosave skips autosaves matching the post.
+func (b *BlogUsecase) clearAutosave(userID, postID uint) {
+	if b.autosaves != nil {
+		b.autosaves.Delete(userID, postID)
+	}
+}
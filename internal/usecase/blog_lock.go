@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+)
+
+func editLockKey(postID uint) string {
+	return fmt.Sprintf("blog:lock:%d", postID)
+}
+
+func (b *BlogUsecase) editLockTTL() time.Duration {
+	return time.Duration(b.cfg.EditLockTTLSec) * time.Second
+}
+
+// AcquireEditLock takes the edit lock on a post the viewer may edit, or
+// extends it if they already hold it. When someone else holds it the
+// returned lock describes them and the error is ErrPostLocked.
+func (b *BlogUsecase) AcquireEditLock(viewer domain.Viewer, postID uint) (*domain.EditLock, error) {
+	post, err := b.editablePost(viewer, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := strconv.FormatUint(uint64(viewer.UserID), 10)
+	holder, err := b.locks.AcquireLease(editLockKey(post.ID), owner, b.editLockTTL())
+	if err != nil {
+		return nil, err
+	}
+	if holder != owner {
+		lock, err := b.currentEditLock(post.ID)
+		if err != nil {
+			return nil, err
+		}
+		return lock, domain.ErrPostLocked
+	}
+
+	return &domain.EditLock{PostID: post.ID, UserID: viewer.UserID, ExpiresAt: time.Now().Add(b.editLockTTL())}, nil
+}
+
+// RenewEditLock is the heartbeat keeping the caller's edit lock alive, as
+// long as the caller may still edit the post.
+func (b *BlogUsecase) RenewEditLock(viewer domain.Viewer, postID uint) (*domain.EditLock, error) {
+	post, err := b.editablePost(viewer, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := strconv.FormatUint(uint64(viewer.UserID), 10)
+	ok, err := b.locks.RenewLease(editLockKey(post.ID), owner, b.editLockTTL())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, domain.ErrLockNotHeld
+	}
+
+	return &domain.EditLock{PostID: post.ID, UserID: viewer.UserID, ExpiresAt: time.Now().Add(b.editLockTTL())}, nil
+}
+
+// ReleaseEditLock gives up the caller's edit lock. With force an editor
+// breaks the lock whoever holds it.
+func (b *BlogUsecase) ReleaseEditLock(viewer domain.Viewer, postID uint, force bool) error {
+	if force {
+		if !b.resolveViewer(viewer).Can(domain.PermPostLockBreak) {
+			return domain.ErrNotEditor
+		}
+		post, err := b.findPost(postID)
+		if err != nil {
+			return err
+		}
+		return b.locks.BreakLease(editLockKey(post.ID))
+	}
+
+	ok, err := b.locks.ReleaseLease(editLockKey(postID), strconv.FormatUint(uint64(viewer.UserID), 10))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrLockNotHeld
+	}
+	return nil
+}
+
+// EditLock returns who is editing a post the viewer may read, or nil when
+// nobody is.
+func (b *BlogUsecase) EditLock(viewer domain.Viewer, postID uint) (*domain.EditLock, error) {
+	post, err := b.findPost(postID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.VisibleTo(b.resolveViewer(viewer), false) {
+		return nil, domain.ErrPostNotFound
+	}
+	return b.currentEditLock(post.ID)
+}
+
+func (b *BlogUsecase) currentEditLock(postID uint) (*domain.EditLock, error) {
+	holder, ttl, err := b.locks.LeaseHolder(editLockKey(postID))
+	if err != nil || holder == "" {
+		return nil, err
+	}
+
+	userID, err := strconv.ParseUint(holder, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.EditLock{PostID: postID, UserID: uint(userID), ExpiresAt: time.Now().Add(ttl)}, nil
+}
+
+// checkEditLock rejects a save while another user holds the post's lock.
+// Saving without taking the lock is allowed when the post is free.
+func (b *BlogUsecase) checkEditLock(userID, postID uint) error {
+	if b.locks == nil {
+		return nil
+	}
+
+	lock, err := b.currentEditLock(postID)
+	if err != nil {
+		return err
+	}
+	if lock != nil && lock.UserID != userID {
+		return domain.ErrPostLocked
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/frontmatter"
+)
+
+// Jekyll style file names carry the publication date: 2021-04-30-my-post.md
+var datedFileName = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-(.+)$`)
+
+// Limits on what an archive may expand to, so that a small zip can't
+// exhaust memory.
+const (
+	maxImportEntries   = 1000
+	maxImportFileSize  = 1 << 20  // per markdown file
+	maxImportTotalSize = 64 << 20 // all markdown files together
+)
+
+// ImportMarkdown creates one post per .md file in the zip archive, owned by
+// the author behind userID. Every file is parsed before anything is written
+// and the posts are created in one transaction, so a malformed archive
+// imports nothing. Returns the number of posts created.
+func (b *BlogUsecase) ImportMarkdown(userID uint, archive []byte) (int, error) {
+	author, err := b.writer(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid zip archive: %w", err)
+	}
+
+	if len(zr.File) > maxImportEntries {
+		return 0, fmt.Errorf("archive has more than %d entries", maxImportEntries)
+	}
+
+	var posts []*domain.BlogPost
+	remaining := int64(maxImportTotalSize)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !strings.EqualFold(path.Ext(f.Name), ".md") || strings.HasPrefix(f.Name, "__MACOSX/") {
+			continue
+		}
+
+		data, err := readZipFile(f, min(maxImportFileSize, remaining))
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		remaining -= int64(len(data))
+
+		post, err := markdownFileToPost(f, data)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		post.AuthorID = author.ID
+		posts = append(posts, post)
+	}
+
+	if len(posts) == 0 {
+		return 0, errors.New("archive contains no markdown files")
+	}
+
+	taken := make(map[string]bool)
+	for _, post := range posts {
+		if err := b.prepareImported(post, taken); err != nil {
+			return 0, err
+		}
+	}
+	if err := b.blogRepo.CreateAll(posts); err != nil {
+		return 0, err
+	}
+
+	return len(posts), nil
+}
+
+// ExportMarkdown returns a zip archive with one front-matter markdown file
+// per post of the author behind userID, in the format ImportMarkdown reads.
+func (b *BlogUsecase) ExportMarkdown(userID uint) ([]byte, error) {
+	author, err := b.authorRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("user is not an author")
+	}
+
+	posts, err := b.blogRepo.FindByAuthorID(b.PublicationID(), author.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	for _, post := range posts {
+		date := post.CreatedAt
+		if post.PublishedAt != nil {
+			date = *post.PublishedAt
+		}
+
+		data, err := frontmatter.Render(frontmatter.Meta{
+			Title:      post.Title,
+			Date:       date.Format(time.RFC3339),
+			Tags:       post.Tags,
+			Categories: post.Categories,
+			Slug:       post.Slug,
+			Draft:      post.IsDraft(),
+		}, post.Content)
+		if err != nil {
+			return nil, err
+		}
+
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     post.Slug + ".md",
+			Method:   zip.Deflate,
+			Modified: post.UpdatedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// readZipFile reads an archive entry, failing once it expands past limit
+// bytes whatever its header claims.
+func readZipFile(f *zip.File, limit int64) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, errors.New("file is too large")
+	}
+	return data, nil
+}
+
+func markdownFileToPost(f *zip.File, data []byte) (*domain.BlogPost, error) {
+	meta, body, err := frontmatter.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSuffix(path.Base(f.Name), path.Ext(f.Name))
+	date := f.Modified
+	if m := datedFileName.FindStringSubmatch(name); m != nil {
+		if t, err := time.Parse("2006-01-02", m[1]); err == nil {
+			date = t
+		}
+		name = m[2]
+	}
+	if meta.Date != "" {
+		if date, err = frontmatter.ParseDate(meta.Date); err != nil {
+			return nil, err
+		}
+	}
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	title := strings.TrimSpace(meta.Title)
+	if title == "" {
+		title = name
+	}
+
+	slug := domain.Slugify(meta.Slug)
+	if meta.Slug == "" {
+		slug = domain.Slugify(name)
+	}
+
+	post := &domain.BlogPost{
+		Slug:       slug,
+		Title:      title,
+		Content:    body,
+		Tags:       domain.NormalizeTags(meta.Tags),
+		Categories: domain.NormalizeTags(meta.Categories),
+		Status:     domain.PostStatusPublished,
+		CreatedAt:  date,
+		UpdatedAt:  date,
+	}
+	if meta.Draft {
+		post.Status = domain.PostStatusDraft
+	} else {
+		post.PublishedAt = &date
+	}
+
+	return post, nil
+}
@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/micropub"
+)
+
+// noteTitleLength is the length of the title made up for posts without a
+// name, such as notes.
+const noteTitleLength = 60
+
+// MicropubCreate creates a post from an h-entry and returns its URL. The
+// entry's name is the title (the start of the content for notes without
+// one, "Photo" for photos), content the content with any photo appended, category the tags,
+// mp-slug the slug, post-status "draft" or "published" and visibility one
+// of the post visibilities.
+func (b *BlogUsecase) MicropubCreate(userID uint, req *micropub.Request) (string, error) {
+	if req.Type != "h-entry" {
+		return "", micropub.InvalidRequest("only h-entry posts are supported")
+	}
+	props := req.Properties
+
+	content := props.String("content")
+	title := strings.TrimSpace(props.String("name"))
+	if title == "" {
+		title = noteTitle(content)
+	}
+	photos := props.Strings("photo")
+	for _, photo := range photos {
+		content = strings.TrimSpace(content + "\n\n![](" + photo + ")")
+	}
+	if title == "" && len(photos) > 0 {
+		title = "Photo"
+	}
+	if title == "" {
+		return "", micropub.InvalidRequest("name, content or photo is required")
+	}
+
+	post := domain.NewBlogPost(0, title, content)
+	post.Tags = domain.NormalizeTags(props.Strings("category"))
+	if slug := props.String("mp-slug"); slug != "" {
+		post.Slug = domain.Slugify(slug)
+	}
+	if v := props.String("visibility"); v != "" {
+		post.Visibility = strings.ToUpper(v)
+		if !domain.ValidVisibility(post.Visibility) {
+			return "", micropub.InvalidRequest("unknown visibility %q", v)
+		}
+	}
+	switch props.String("post-status") {
+	case "", "published":
+	case "draft":
+		post.Status = domain.PostStatusDraft
+		post.PublishedAt = nil
+	default:
+		return "", micropub.InvalidRequest("post-status must be draft or published")
+	}
+
+	if err := b.createPost(userID, post); err != nil {
+		return "", err
+	}
+	return b.PostURL(post.Slug), nil
+}
+
+// MicropubUpdate applies the replace, add and delete changes of an update
+// request to the post at req.URL. Only name, content, category and
+// visibility can be changed.
+func (b *BlogUsecase) MicropubUpdate(userID uint, req *micropub.Request) error {
+	post, err := b.ownPostByURL(userID, req.URL)
+	if err != nil {
+		return err
+	}
+
+	changes := domain.PostChanges{Title: post.Title, Content: post.Content, Tags: post.Tags}
+	if changes.Tags == nil {
+		changes.Tags = []string{}
+	}
+	for _, set := range []micropub.Properties{req.Replace, req.Add, req.Delete} {
+		for name := range set {
+			if !slices.Contains([]string{"name", "content", "category", "visibility"}, name) {
+				return micropub.InvalidRequest("%s cannot be updated", name)
+			}
+		}
+	}
+
+	if _, ok := req.Replace["name"]; ok {
+		changes.Title = req.Replace.String("name")
+	}
+	if _, ok := req.Replace["content"]; ok {
+		changes.Content = req.Replace.String("content")
+	}
+	if _, ok := req.Replace["category"]; ok {
+		changes.Tags = req.Replace.Strings("category")
+	}
+	if v := req.Replace.String("visibility"); v != "" {
+		changes.Visibility = strings.ToUpper(v)
+	}
+	changes.Tags = append(changes.Tags, req.Add.Strings("category")...)
+	for name, values := range req.Delete {
+		switch {
+		case name == "category" && values != nil:
+			remove := req.Delete.Strings("category")
+			changes.Tags = slices.DeleteFunc(changes.Tags, func(t string) bool {
+				return slices.Contains(remove, t)
+			})
+		case name == "category":
+			changes.Tags = []string{}
+		case name == "content":
+			changes.Content = ""
+		default:
+			return micropub.InvalidRequest("%s cannot be removed", name)
+		}
+	}
+
+	_, err = b.UpdatePost(domain.Viewer{UserID: userID}, post.ID, post.Version, changes)
+	return err
+}
+
+// MicropubDelete deletes the post at postURL.
+func (b *BlogUsecase) MicropubDelete(userID uint, postURL string) error {
+	post, err := b.ownPostByURL(userID, postURL)
+	if err != nil {
+		return err
+	}
+	return b.DeletePost(userID, post.ID, 0)
+}
+
+// MicropubSource returns the post at postURL as an h-entry, limited to the
+// given properties if any are named.
+func (b *BlogUsecase) MicropubSource(userID uint, postURL string, properties []string) (*micropub.Entry, error) {
+	post, err := b.ownPostByURL(userID, postURL)
+	if err != nil {
+		return nil, err
+	}
+
+	status := "published"
+	if post.Status != domain.PostStatusPublished {
+		status = "draft"
+	}
+	props := micropub.Properties{
+		"name":        {post.Title},
+		"content":     {post.Content},
+		"category":    toAny(post.Tags),
+		"post-status": {status},
+		"visibility":  {strings.ToLower(post.Visibility)},
+		"url":         {b.PostURL(post.Slug)},
+	}
+	if post.PublishedAt != nil {
+		props["published"] = []any{post.PublishedAt.Format(time.RFC3339)}
+	}
+
+	if len(properties) == 0 {
+		return &micropub.Entry{Type: []string{"h-entry"}, Properties: props}, nil
+	}
+	selected := micropub.Properties{}
+	for _, name := range properties {
+		if values, ok := props[name]; ok {
+			selected[name] = values
+		}
+	}
+	return &micropub.Entry{Properties: selected}, nil
+}
+
+// MicropubConfig is the q=config response.
+func (b *BlogUsecase) MicropubConfig() *micropub.Config {
+	return &micropub.Config{
+		MediaEndpoint: b.cfg.MediaURL,
+		SyndicateTo:   []any{},
+		Q:             []string{"config", "source", "syndicate-to"},
+		PostTypes: []micropub.PostType{
+			{Type: "article", Name: "Post"},
+			{Type: "note", Name: "Note"},
+			{Type: "photo", Name: "Photo"},
+		},
+	}
+}
+
+// ownPostByURL loads a post of the author behind userID by its URL, drafts
+// included.
+func (b *BlogUsecase) ownPostByURL(userID uint, postURL string) (*domain.BlogPost, error) {
+	slug, ok := b.slugFromURL(postURL)
+	if !ok {
+		return nil, domain.ErrPostNotFound
+	}
+	post, err := b.blogRepo.FindBySlug(b.PublicationID(), slug)
+	if err != nil {
+		return nil, err
+	}
+	return b.ownPost(userID, post.ID)
+}
+
+// noteTitle makes a title from the first line of content.
+func noteTitle(content string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(content), "\n")
+	line = strings.TrimSpace(line)
+	if r := []rune(line); len(r) > noteTitleLength {
+		line = strings.TrimSpace(string(r[:noteTitleLength])) + "…"
+	}
+	return line
+}
+
+func toAny(values []string) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"bytes"
+	"html/template"
+	"net/url"
+	"strings"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/oembed"
+)
+
+// embedSize is the post card's preferred and smallest usable size.
+var embedSize = oembed.Size{Width: 600, Height: 240, MinWidth: 240, MinHeight: 120}
+
+var embedCard = template.Must(template.New("embed").Parse(
+	`<blockquote class="blog-embed" style="max-width:{{.Width}}px;max-height:{{.Height}}px;overflow:hidden">` +
+		`<p><strong><a href="{{.URL}}">{{.Title}}</a></strong></p>` +
+		`<p>{{.Excerpt}}</p>` +
+		`<p><a href="{{.SiteURL}}">{{.SiteName}}</a></p>` +
+		`</blockquote>`))
+
+// OEmbed returns the oEmbed card of the post at pageURL, sized to fit within
+// maxWidth and maxHeight (0 for no limit). Only posts an anonymous reader
+// may open by link can be embedded.
+func (b *BlogUsecase) OEmbed(pageURL string, maxWidth, maxHeight int) (*oembed.Response, error) {
+	slug, ok := b.slugFromURL(pageURL)
+	if !ok {
+		return nil, domain.ErrPostNotFound
+	}
+
+	post, err := b.GetPost(domain.Viewer{}, 0, slug, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	width, height, err := embedSize.Fit(maxWidth, maxHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	var html bytes.Buffer
+	err = embedCard.Execute(&html, map[string]any{
+		"Width":    width,
+		"Height":   height,
+		"URL":      b.PostURL(post.Slug),
+		"Title":    post.Title,
+		"Excerpt":  post.Description(),
+		"SiteURL":  b.cfg.PublicURL,
+		"SiteName": b.cfg.SiteName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &oembed.Response{
+		Type:         "rich",
+		Version:      "1.0",
+		Title:        post.Title,
+		ProviderName: b.cfg.SiteName,
+		ProviderURL:  b.cfg.PublicURL,
+		CacheAge:     3600,
+		HTML:         html.String(),
+		Width:        width,
+		Height:       height,
+	}, nil
+}
+
+// OEmbedDiscoveryURL is the oEmbed endpoint address for a post in the given
+// format, "json" or "xml".
+func (b *BlogUsecase) OEmbedDiscoveryURL(slug, format string) string {
+	return oembed.DiscoveryURL(b.cfg.OEmbedURL, b.PostURL(slug), format)
+}
+
+// slugFromURL extracts the slug of a post URL as built by PostURL.
+func (b *BlogUsecase) slugFromURL(raw string) (string, bool) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	u.RawQuery, u.Fragment = "", ""
+
+	slug, ok := strings.CutPrefix(strings.TrimRight(u.String(), "/"), b.PostURL(""))
+	if !ok || slug == "" || strings.Contains(slug, "/") {
+		return "", false
+	}
+	return slug, true
+}
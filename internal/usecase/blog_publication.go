@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+)
+
+// ForPublication returns the usecase scoped to pub, whose posts, slugs and
+// settings it then works with. A nil pub is the default publication.
+func (b *BlogUsecase) ForPublication(pub *domain.Publication) *BlogUsecase {
+	if pub == nil {
+		return b
+	}
+
+	scoped := *b
+	scoped.pub = pub
+	scoped.cfg.SiteName = pub.Name
+	scoped.cfg.RequireReview = pub.Settings.RequireReview
+	if pub.Settings.DefaultLocale != "" {
+		scoped.cfg.DefaultLocale = pub.Settings.DefaultLocale
+	}
+	if pub.CustomDomain != "" {
+		scoped.cfg.PublicURL = "https://" + pub.CustomDomain
+		scoped.cfg.OEmbedURL = scoped.cfg.PublicURL + "/oembed"
+		scoped.cfg.WebmentionURL = scoped.cfg.PublicURL + "/webmention"
+	} else {
+		id := strconv.FormatUint(uint64(pub.ID), 10)
+		scoped.cfg.PublicURL = strings.TrimRight(b.cfg.PublicURL, "/") + "/publications/" + pub.Slug
+		scoped.cfg.OEmbedURL = b.cfg.OEmbedURL + "?publication_id=" + id
+		scoped.cfg.WebmentionURL = b.cfg.WebmentionURL + "?publication_id=" + id
+	}
+	return &scoped
+}
+
+// PublicationID is the ID of the publication the usecase is scoped to.
+func (b *BlogUsecase) PublicationID() uint {
+	if b.pub == nil {
+		return domain.DefaultPublicationID
+	}
+	return b.pub.ID
+}
+
+// findPost loads a post of the current publication by ID. Posts of other
+// publications are not found.
+func (b *BlogUsecase) findPost(postID uint) (*domain.BlogPost, error) {
+	post, err := b.blogRepo.FindByID(postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.PublicationID != b.PublicationID() {
+		return nil, domain.ErrPostNotFound
+	}
+	return post, nil
+}
+
+// checkWriter lets only a publication's members write in it. Anyone who is
+// an author writes in the default publication.
+func (b *BlogUsecase) checkWriter(userID uint) error {
+	if b.pub == nil {
+		return nil
+	}
+	_, err := b.publications.FindMember(b.pub.ID, userID)
+	return err
+}
+
+// publicationRoles returns the viewer's roles in the current publication.
+// Within a team publication only its owners and editors act as editors.
+func (b *BlogUsecase) publicationRoles(v domain.Viewer) []string {
+	if b.pub == nil || !v.LoggedIn() {
+		return v.Roles
+	}
+
+	// Editors of the deployment are not editors of team publications.
+	roles := make([]string, 0, len(v.Roles)+1)
+	for _, role := range v.Roles {
+		if role != domain.RoleEditor {
+			roles = append(roles, role)
+		}
+	}
+	member, err := b.publications.FindMember(b.pub.ID, v.UserID)
+	if err == nil && (member.Role == domain.PublicationOwner || member.Role == domain.PublicationEditor) {
+		roles = append(roles, domain.RoleEditor)
+	}
+	return roles
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+)
+
+// SubmitForReview sends one of the author's drafts to the editors.
+func (b *BlogUsecase) SubmitForReview(userID, postID uint) (*domain.BlogPost, error) {
+	post, err := b.ownPost(userID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	version := post.Version
+	if err := post.SubmitForReview(); err != nil {
+		return nil, err
+	}
+	if err := b.blogRepo.Update(post, version); err != nil {
+		return nil, err
+	}
+
+	event := domain.NewPostEvent(domain.EventReviewSubmitted, post)
+	event.ActorUserID = userID
+	return post, b.publishEvent(event)
+}
+
+// ReviewPost records an editor's decision on a post in review. Comments are
+// only accepted when requesting changes. Reviews take a second person, so
+// editors can't review their own posts.
+func (b *BlogUsecase) ReviewPost(editor domain.Viewer, postID uint, decision, note string, comments []domain.ReviewComment) (*domain.BlogPost, error) {
+	editor = b.resolveViewer(editor)
+	if !editor.Can(domain.PermPostReview) {
+		return nil, domain.ErrNotEditor
+	}
+	if len(comments) > 0 && decision != domain.ReviewRequestChanges {
+		return nil, errors.New("comments can only be attached when requesting changes")
+	}
+	for i := range comments {
+		if strings.TrimSpace(comments[i].Body) == "" {
+			return nil, errors.New("review comment cannot be empty")
+		}
+		comments[i].BlogID = postID
+		comments[i].EditorUserID = editor.UserID
+	}
+
+	post, err := b.findPost(postID)
+	if err != nil {
+		return nil, err
+	}
+	if editor.AuthorID != 0 && editor.AuthorID == post.AuthorID {
+		return nil, domain.ErrOwnPostReview
+	}
+
+	version := post.Version
+	eventType, err := post.Review(decision)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.blogRepo.UpdateReviewed(post, version, comments); err != nil {
+		return nil, err
+	}
+
+	event := domain.NewPostEvent(eventType, post)
+	event.ActorUserID = editor.UserID
+	event.Note = note
+	event.Comments = comments
+	return post, b.publishEvent(event)
+}
+
+// PublishPost makes one of the viewer's posts, or anyone's with
+// post:publish:any, public. When reviews are required only approved posts
+// can be published.
+func (b *BlogUsecase) PublishPost(viewer domain.Viewer, postID uint) (*domain.BlogPost, error) {
+	var post *domain.BlogPost
+	var err error
+	if b.resolveViewer(viewer).Can(domain.PermPostPublishAny) {
+		post, err = b.findPost(postID)
+	} else {
+		post, err = b.ownPost(viewer.UserID, postID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	version := post.Version
+	if err := post.Publish(b.cfg.RequireReview); err != nil {
+		return nil, err
+	}
+	if err := b.blogRepo.Update(post, version); err != nil {
+		return nil, err
+	}
+
+	return post, b.publish(domain.EventPostPublished, post)
+}
+
+// ReviewQueue lists the posts waiting for an editor.
+func (b *BlogUsecase) ReviewQueue(editor domain.Viewer) ([]*domain.BlogPost, error) {
+	if !b.resolveViewer(editor).Can(domain.PermPostReview) {
+		return nil, domain.ErrNotEditor
+	}
+
+	return b.blogRepo.List(domain.PostFilter{
+		PublicationID: b.PublicationID(),
+		Status:        domain.PostStatusInReview,
+		Limit:         maxListLimit,
+	})
+}
+
+// ReviewComments returns the editors' comments on a post to its author or an editor.
+func (b *BlogUsecase) ReviewComments(viewer domain.Viewer, postID uint) ([]domain.ReviewComment, error) {
+	post, err := b.findPost(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	viewer = b.resolveViewer(viewer)
+	if !viewer.Can(domain.PermPostReview) && viewer.AuthorID != post.AuthorID {
+		return nil, domain.ErrPostNotFound
+	}
+	return b.blogRepo.FindReviewComments(post.ID)
+}
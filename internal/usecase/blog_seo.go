@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/seo"
+)
+
+// maxMetaDescription caps an author-written meta description.
+const maxMetaDescription = 300
+
+func validateSEO(s *domain.PostSEO) error {
+	if len([]rune(s.MetaDescription)) > maxMetaDescription {
+		return errors.New("meta description is too long")
+	}
+	for _, u := range []string{s.CanonicalURL, s.SocialImage} {
+		if u != "" && !absoluteURL(u) {
+			return errors.New("canonical URL and social image must be absolute http(s) URLs")
+		}
+	}
+	return nil
+}
+
+func absoluteURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// PostURL is the public address of a post.
+func (b *BlogUsecase) PostURL(slug string) string {
+	return strings.TrimRight(b.cfg.PublicURL, "/") + "/posts/" + slug
+}
+
+// PostMeta returns the search and social preview metadata of a post the
+// viewer may read, in the best locale for the preferred list.
+func (b *BlogUsecase) PostMeta(viewer domain.Viewer, id uint, slug string, preferred []string) (*seo.Meta, error) {
+	post, err := b.GetPost(viewer, id, slug, preferred)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &seo.Meta{
+		Title:       post.Title,
+		Description: post.Description(),
+		URL:         b.PostURL(post.Slug),
+		Canonical:   post.SEO.CanonicalURL,
+		Image:       post.Image(),
+		SiteName:    b.cfg.SiteName,
+		Locale:      strings.ReplaceAll(post.Locale, "-", "_"),
+		Tags:        post.Tags,
+		NoIndex:     !post.Indexable(),
+		PublishedAt: post.PublishedAt,
+		ModifiedAt:  post.UpdatedAt,
+	}
+	if m.Canonical == "" {
+		m.Canonical = m.URL
+	}
+	if post.VisibleTo(domain.Viewer{}, true) {
+		m.OEmbedJSON = b.OEmbedDiscoveryURL(post.Slug, "json")
+		m.OEmbedXML = b.OEmbedDiscoveryURL(post.Slug, "xml")
+		m.Webmention = b.WebmentionURL()
+	}
+	// Relative image paths in the content are resolved against the post.
+	if m.Image != "" && !absoluteURL(m.Image) {
+		if base, err := url.Parse(m.URL); err == nil {
+			if ref, err := url.Parse(m.Image); err == nil {
+				m.Image = base.ResolveReference(ref).String()
+			}
+		}
+	}
+	return m, nil
+}
@@ -2,47 +2,466 @@ package usecase
 
 import (
 	"errors"
-	"time"
+	"fmt"
+	"log"
+	"strings"
 
+	"github.com/Hamiduzzaman96/Blog-Service/config"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/i18n"
 	"github.com/Hamiduzzaman96/Blog-Service/pkg/rabbitmq"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/redis"
 )
 
+// maxListLimit caps the page size of ListPosts.
+const maxListLimit = 100
+
 type BlogUsecase struct {
-	blogRepo   *repository.BlogRepository
-	authorRepo *repository.AuthorRepository
-	mq         *rabbitmq.Client
+	blogRepo     *repository.BlogRepository
+	authorRepo   *repository.AuthorRepository
+	userRepo     *repository.UserRepository
+	eventRepo    *repository.PostEventRepository
+	autosaves    *repository.AutosaveRepository
+	publications *repository.PublicationRepository
+	mq           *rabbitmq.Client
+	locks        *redis.Client
+	cfg          config.BlogConfig
+
+	// pub is the publication the usecase is scoped to, nil for the default
+	// one; see ForPublication.
+	pub *domain.Publication
 }
 
 func NewBlogUsecase(
 	blogRepo *repository.BlogRepository,
 	authorRepo *repository.AuthorRepository,
+	userRepo *repository.UserRepository,
+	eventRepo *repository.PostEventRepository,
+	autosaves *repository.AutosaveRepository,
+	publications *repository.PublicationRepository,
 	mq *rabbitmq.Client,
+	locks *redis.Client,
+	cfg config.BlogConfig,
 ) *BlogUsecase {
 	return &BlogUsecase{
-		blogRepo:   blogRepo,
-		authorRepo: authorRepo,
-		mq:         mq,
+		blogRepo:     blogRepo,
+		authorRepo:   authorRepo,
+		userRepo:     userRepo,
+		eventRepo:    eventRepo,
+		autosaves:    autosaves,
+		publications: publications,
+		mq:           mq,
+		locks:        locks,
+		cfg:          cfg,
 	}
 }
 
-func (b *BlogUsecase) CreatePost(userID uint, title, content string) error {
-	author, err := b.authorRepo.FindByUserID(userID)
+func (b *BlogUsecase) CreatePost(userID uint, title, content, visibility string) error {
+	post := domain.NewBlogPost(0, title, content)
+	if visibility != "" {
+		if !domain.ValidVisibility(visibility) {
+			return errors.New("invalid visibility")
+		}
+		post.Visibility = visibility
+	}
+	return b.createPost(userID, post)
+}
+
+// createPost stores a new post for the author behind userID in the current
+// publication, held back as a draft when posts need review, and announces
+// it.
+func (b *BlogUsecase) createPost(userID uint, post *domain.BlogPost) error {
+	author, err := b.writer(userID)
 	if err != nil {
-		return errors.New("user is not an author")
+		return err
+	}
+
+	post.AuthorID = author.ID
+	post.PublicationID = b.PublicationID()
+	post.Locale = b.cfg.DefaultLocale
+	if b.cfg.RequireReview {
+		post.Status = domain.PostStatusDraft
+		post.PublishedAt = nil
 	}
 
-	post := &domain.BlogPost{
-		AuthorID:  author.ID,
-		Title:     title,
-		Content:   content,
-		CreatedAt: time.Now(),
+	slug, err := b.uniqueSlug(post.Slug, nil)
+	if err != nil {
+		return err
 	}
+	post.Slug = slug
 
 	if _, err := b.blogRepo.Create(post); err != nil {
 		return err
 	}
+	b.clearAutosave(userID, 0)
+
+	if err := b.publish(domain.EventPostCreated, post); err != nil {
+		return err
+	}
+	if post.Status == domain.PostStatusPublished {
+		return b.publish(domain.EventPostPublished, post)
+	}
+	return nil
+}
+
+// writer returns the author behind userID if they may create posts in the
+// current publication: their email must be verified, their account not
+// suspended, and they must be a member of a team publication. Every path
+// that creates posts, imports included, goes through it.
+func (b *BlogUsecase) writer(userID uint) (*domain.Author, error) {
+	author, err := b.authorRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("user is not an author")
+	}
+	user, err := b.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Banned {
+		return nil, domain.ErrUserBanned
+	}
+	if !user.EmailVerified {
+		return nil, domain.ErrEmailNotVerified
+	}
+	if err := b.checkWriter(userID); err != nil {
+		return nil, err
+	}
+	return author, nil
+}
+
+// UpdatePost edits a post the viewer may edit, which fails while someone
+// else holds its edit lock. expectedVersion must be the
+// version the editor started from, or domain.AnyVersion; if someone saved in
+// the meantime the update is rejected with domain.ErrVersionConflict.
+func (b *BlogUsecase) UpdatePost(viewer domain.Viewer, postID, expectedVersion uint, changes domain.PostChanges) (*domain.BlogPost, error) {
+	if expectedVersion == 0 {
+		return nil, domain.ErrVersionRequired
+	}
+	if strings.TrimSpace(changes.Title) == "" {
+		return nil, errors.New("title cannot be empty")
+	}
+	if changes.Visibility != "" && !domain.ValidVisibility(changes.Visibility) {
+		return nil, errors.New("invalid visibility")
+	}
+	if changes.SEO != nil {
+		if err := validateSEO(changes.SEO); err != nil {
+			return nil, err
+		}
+	}
+	if changes.RequiredTier != nil && *changes.RequiredTier != "" && !domain.ValidTier(*changes.RequiredTier) {
+		return nil, domain.ErrInvalidTier
+	}
+
+	post, err := b.editablePost(viewer, postID)
+	if err != nil {
+		return nil, err
+	}
+	if expectedVersion == domain.AnyVersion {
+		expectedVersion = post.Version
+	}
+	if post.Version != expectedVersion {
+		return nil, domain.ErrVersionConflict
+	}
+	if err := b.checkEditLock(viewer.UserID, post.ID); err != nil {
+		return nil, err
+	}
+
+	post.Update(changes.Title, changes.Content)
+	if post.Status == domain.PostStatusApproved {
+		// The approval was for the previous content.
+		post.Status = domain.PostStatusDraft
+	}
+	if changes.Tags != nil {
+		post.Tags = domain.NormalizeTags(changes.Tags)
+	}
+	if changes.Visibility != "" {
+		post.Visibility = changes.Visibility
+	}
+	if changes.SEO != nil {
+		post.SEO = *changes.SEO
+	}
+	if changes.RequiredTier != nil {
+		post.RequiredTier = *changes.RequiredTier
+	}
+
+	if err := b.blogRepo.Update(post, expectedVersion); err != nil {
+		return nil, err
+	}
+	b.clearAutosave(viewer.UserID, post.ID)
+
+	if err := b.publish(domain.EventPostUpdated, post); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// DeletePost removes one of the author's posts with its translations and
+// comments. An expectedVersion other than zero or domain.AnyVersion guards
+// against deleting a post that changed since the caller last read it.
+func (b *BlogUsecase) DeletePost(userID, postID, expectedVersion uint) error {
+	post, err := b.ownPost(userID, postID)
+	if err != nil {
+		return err
+	}
+	if expectedVersion != 0 && expectedVersion != domain.AnyVersion && post.Version != expectedVersion {
+		return domain.ErrVersionConflict
+	}
+
+	if err := b.blogRepo.Delete(post.ID); err != nil {
+		return err
+	}
+	return b.publish(domain.EventPostDeleted, post)
+}
+
+// publish announces a change to post.
+func (b *BlogUsecase) publish(eventType string, post *domain.BlogPost) error {
+	return b.publishEvent(domain.NewPostEvent(eventType, post))
+}
+
+// publishEvent records a post event, which assigns its sequence number, and
+// sends it to the exchange under the event type as routing key. The change
+// is committed by then, so a failure to reach the exchange is only logged:
+// returning it would make clients retry a write that already happened.
+func (b *BlogUsecase) publishEvent(event *domain.PostEvent) error {
+	if author, err := b.authorRepo.FindByID(event.AuthorID); err == nil {
+		event.AuthorUserID = author.UserID
+	}
+	if err := b.eventRepo.Create(event); err != nil {
+		return err
+	}
+	if err := b.mq.Publish(event.Type, event); err != nil {
+		log.Printf("failed to publish %s of post %d: %v", event.Type, event.PostID, err)
+	}
+	return nil
+}
 
-	return b.mq.Publish("blog.created", post)
+// ImportPost stores a post migrated from another platform for the author
+// behind userID, keeping its dates, status and (if still free) slug. The post
+// is recorded under ref together with its creation.
+func (b *BlogUsecase) ImportPost(userID uint, post *domain.BlogPost, ref domain.ImportRef) (*domain.BlogPost, error) {
+	author, err := b.writer(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	post.AuthorID = author.ID
+	if post.Slug == "" {
+		post.Slug = domain.Slugify(post.Title)
+	}
+	post.Tags = domain.NormalizeTags(post.Tags)
+	post.Categories = domain.NormalizeTags(post.Categories)
+
+	if err := b.prepareImported(post, nil); err != nil {
+		return nil, err
+	}
+	if err := b.blogRepo.CreateImported(post, ref); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// prepareImported places an imported post in the current publication and
+// picks a slug that is free there and not in taken. Like new posts, imported
+// ones are held back as drafts when posts need review. No event is published
+// for imported posts.
+func (b *BlogUsecase) prepareImported(post *domain.BlogPost, taken map[string]bool) error {
+	post.PublicationID = b.PublicationID()
+	if post.Locale == "" {
+		post.Locale = b.cfg.DefaultLocale
+	}
+	if b.cfg.RequireReview {
+		post.Status = domain.PostStatusDraft
+		post.PublishedAt = nil
+	}
+
+	slug, err := b.uniqueSlug(post.Slug, taken)
+	if err != nil {
+		return err
+	}
+	post.Slug = slug
+	return nil
+}
+
+// GetPost returns a post by ID or, if id is zero, by slug, in the best
+// locale for the preferred list (most preferred first). Posts the viewer
+// may not see are reported as not found.
+func (b *BlogUsecase) GetPost(viewer domain.Viewer, id uint, slug string, preferred []string) (*domain.PostView, error) {
+	var post *domain.BlogPost
+	var err error
+	if id != 0 {
+		post, err = b.findPost(id)
+	} else {
+		post, err = b.blogRepo.FindBySlug(b.PublicationID(), slug)
+	}
+	if err != nil {
+		return nil, err
+	}
+	viewer = b.resolveViewer(viewer)
+	if !post.VisibleTo(viewer, id == 0) {
+		return nil, domain.ErrPostNotFound
+	}
+
+	translations, err := b.blogRepo.FindTranslations(post.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	view := b.localize(post, translations[post.ID], preferred)
+	view.ApplyPaywall(viewer)
+	return view, nil
+}
+
+// ListPosts returns the published posts matching the filter that the viewer
+// may see listed, each in the best locale for the preferred list.
+func (b *BlogUsecase) ListPosts(viewer domain.Viewer, f domain.PostFilter, preferred []string) ([]*domain.PostView, error) {
+	viewer = b.resolveViewer(viewer)
+
+	f.PublicationID = b.PublicationID()
+	f.Status = domain.PostStatusPublished
+	f.Visibilities = viewer.ListedVisibilities()
+	f.OwnerAuthorID = viewer.AuthorID
+	if f.Limit <= 0 || f.Limit > maxListLimit {
+		f.Limit = maxListLimit
+	}
+
+	posts, err := b.blogRepo.List(f)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(posts))
+	for _, p := range posts {
+		ids = append(ids, p.ID)
+	}
+	translations, err := b.blogRepo.FindTranslations(ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]*domain.PostView, 0, len(posts))
+	for _, p := range posts {
+		view := b.localize(p, translations[p.ID], preferred)
+		view.ApplyPaywall(viewer)
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// resolveViewer fills in the author ID of a logged-in viewer, so they are
+// recognised as the owner of their posts, and their roles in the publication.
+func (b *BlogUsecase) resolveViewer(v domain.Viewer) domain.Viewer {
+	v.Roles = b.publicationRoles(v)
+	if v.LoggedIn() && v.AuthorID == 0 {
+		if author, err := b.authorRepo.FindByUserID(v.UserID); err == nil {
+			v.AuthorID = author.ID
+		}
+	}
+	return v
+}
+
+// SetTranslation adds or replaces the post's title and content for a locale.
+// Only the post's author may translate it.
+func (b *BlogUsecase) SetTranslation(userID, postID uint, locale, title, content string) error {
+	locale, err := i18n.Normalize(locale)
+	if err != nil {
+		return errors.New("invalid locale")
+	}
+	if strings.TrimSpace(title) == "" {
+		return errors.New("title cannot be empty")
+	}
+
+	post, err := b.ownPost(userID, postID)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(locale, b.postLocale(post)) {
+		return errors.New("locale is the post's original locale")
+	}
+
+	return b.blogRepo.UpsertTranslation(&domain.PostTranslation{
+		BlogID:  post.ID,
+		Locale:  locale,
+		Title:   title,
+		Content: content,
+	})
+}
+
+// ownPost loads a post and checks that it belongs to the author behind userID.
+func (b *BlogUsecase) ownPost(userID, postID uint) (*domain.BlogPost, error) {
+	author, err := b.authorRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("user is not an author")
+	}
+
+	post, err := b.findPost(postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.AuthorID != author.ID {
+		return nil, errors.New("post belongs to another author")
+	}
+	return post, nil
+}
+
+// editablePost loads a post the viewer may edit: their own, or any post of
+// the publication when they review or publish others' posts.
+func (b *BlogUsecase) editablePost(viewer domain.Viewer, postID uint) (*domain.BlogPost, error) {
+	viewer = b.resolveViewer(viewer)
+	if viewer.Can(domain.PermPostReview) || viewer.Can(domain.PermPostPublishAny) {
+		return b.findPost(postID)
+	}
+	return b.ownPost(viewer.UserID, postID)
+}
+
+func (b *BlogUsecase) postLocale(post *domain.BlogPost) string {
+	if post.Locale == "" {
+		return b.cfg.DefaultLocale
+	}
+	return post.Locale
+}
+
+// localize picks the translation matching the reader's preferences, then the
+// configured fallback chain, and finally the post's original locale.
+func (b *BlogUsecase) localize(post *domain.BlogPost, translations []*domain.PostTranslation, preferred []string) *domain.PostView {
+	original := b.postLocale(post)
+	post.Locale = original
+
+	available := []string{original}
+	for _, t := range translations {
+		available = append(available, t.Locale)
+	}
+
+	view := &domain.PostView{BlogPost: post, AvailableLocales: available}
+
+	chosen := i18n.Match(available, preferred, b.cfg.LocaleFallback)
+	for _, t := range translations {
+		if t.Locale == chosen {
+			view.BlogPost = post.Translate(t)
+			break
+		}
+	}
+	return view
+}
+
+// uniqueSlug appends a numeric suffix until the slug is free. Slugs in taken
+// count as used, and the chosen one is added to it; taken may be nil.
+func (b *BlogUsecase) uniqueSlug(base string, taken map[string]bool) (string, error) {
+	slug := base
+	for i := 2; ; i++ {
+		exists := taken[slug]
+		if !exists {
+			var err error
+			if exists, err = b.blogRepo.SlugExists(b.PublicationID(), slug); err != nil {
+				return "", err
+			}
+		}
+		if !exists {
+			if taken != nil {
+				taken[slug] = true
+			}
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
 }
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+)
+
+// WebmentionURL is the public address of the publication's Webmention
+// endpoint.
+func (b *BlogUsecase) WebmentionURL() string {
+	return b.cfg.WebmentionURL
+}
+
+// MentionedPost returns the post at target, a post URL as built by PostURL.
+// Only posts an anonymous reader may open by link can be mentioned.
+func (b *BlogUsecase) MentionedPost(target string) (*domain.BlogPost, error) {
+	slug, ok := b.slugFromURL(target)
+	if !ok {
+		return nil, domain.ErrPostNotFound
+	}
+	post, err := b.GetPost(domain.Viewer{}, 0, slug, nil)
+	if err != nil {
+		return nil, err
+	}
+	return post.BlogPost, nil
+}
+
+// forPublicationID returns the usecase scoped to the publication with the
+// given ID.
+func (b *BlogUsecase) forPublicationID(id uint) (*BlogUsecase, error) {
+	if id == domain.DefaultPublicationID {
+		return b, nil
+	}
+	pub, err := b.publications.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return b.ForPublication(pub), nil
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
+)
+
+type CommentUsecase struct {
+	commentRepo *repository.CommentRepository
+	blogRepo    *repository.BlogRepository
+}
+
+func NewCommentUsecase(
+	commentRepo *repository.CommentRepository,
+	blogRepo *repository.BlogRepository,
+) *CommentUsecase {
+	return &CommentUsecase{
+		commentRepo: commentRepo,
+		blogRepo:    blogRepo,
+	}
+}
+
+// ImportComment stores a comment taken from another platform as is,
+// keeping its original date and moderation status, together with the
+// import record of ref.
+func (c *CommentUsecase) ImportComment(comment *domain.Comment, ref domain.ImportRef) (*domain.Comment, error) {
+	if strings.TrimSpace(comment.Content) == "" {
+		return nil, errors.New("comment content cannot be empty")
+	}
+	if _, err := c.blogRepo.FindByID(comment.BlogID); err != nil {
+		return nil, err
+	}
+	if comment.Status == "" {
+		comment.Status = domain.CommentStatusPending
+	}
+
+	return c.commentRepo.CreateImported(comment, ref)
+}
+
+func (c *CommentUsecase) ListApproved(blogID uint) ([]*domain.Comment, error) {
+	return c.commentRepo.FindByBlogID(blogID, domain.CommentStatusApproved)
+}
@@ -0,0 +1,542 @@
+package usecase
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/config"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/activitypub"
+)
+
+const (
+	// outboxPageSize is the number of activities per outbox page.
+	outboxPageSize = 20
+	// deliveryBatch is the number of deliveries attempted per run.
+	deliveryBatch = 50
+)
+
+// FederationUsecase makes authors ActivityPub actors: it serves their
+// actor, outbox and WebFinger documents, handles Follow and Undo sent to
+// their inboxes and delivers their new posts to followers.
+//
+// Only posts of the default publication are federated, as the actors live
+// on BLOG_PUBLIC_URL.
+type FederationUsecase struct {
+	fedRepo    *repository.FederationRepository
+	authorRepo *repository.AuthorRepository
+	blogRepo   *repository.BlogRepository
+	client     *activitypub.Client
+	blogs      *BlogUsecase
+	cfg        config.BlogConfig
+}
+
+func NewFederationUsecase(
+	fedRepo *repository.FederationRepository,
+	authorRepo *repository.AuthorRepository,
+	blogRepo *repository.BlogRepository,
+	client *activitypub.Client,
+	blogs *BlogUsecase,
+	cfg config.BlogConfig,
+) *FederationUsecase {
+	return &FederationUsecase{
+		fedRepo:    fedRepo,
+		authorRepo: authorRepo,
+		blogRepo:   blogRepo,
+		client:     client,
+		blogs:      blogs,
+		cfg:        cfg,
+	}
+}
+
+// ADDRESSES
+
+func (f *FederationUsecase) endpoint(path string, id uint) string {
+	return strings.TrimRight(f.cfg.PublicURL, "/") + path + "?id=" + strconv.FormatUint(uint64(id), 10)
+}
+
+// ActorURL is the ActivityPub ID of an author.
+func (f *FederationUsecase) ActorURL(authorID uint) string {
+	return f.endpoint("/ap/actor", authorID)
+}
+
+func (f *FederationUsecase) keyID(authorID uint) string {
+	return f.ActorURL(authorID) + "#main-key"
+}
+
+func (f *FederationUsecase) followersURL(authorID uint) string {
+	return f.endpoint("/ap/followers", authorID)
+}
+
+// objectURL is the ActivityPub ID of a post, served as an Article.
+func (f *FederationUsecase) objectURL(postID uint) string {
+	return f.endpoint("/ap/post", postID)
+}
+
+// handle is the author's WebFinger user name.
+func handle(authorID uint) string {
+	return "author" + strconv.FormatUint(uint64(authorID), 10)
+}
+
+func (f *FederationUsecase) host() string {
+	u, err := url.Parse(f.cfg.PublicURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// DOCUMENTS
+
+// WebFinger resolves acct:author{id}@host, or an actor URL, to the actor.
+func (f *FederationUsecase) WebFinger(resource string) (*activitypub.JRD, error) {
+	var authorID uint64
+	if acct, ok := strings.CutPrefix(resource, "acct:"); ok {
+		user, host, _ := strings.Cut(acct, "@")
+		id, ok := strings.CutPrefix(user, "author")
+		if !ok || !strings.EqualFold(host, f.host()) {
+			return nil, domain.ErrActorNotFound
+		}
+		authorID, _ = strconv.ParseUint(id, 10, 64)
+	} else {
+		u, err := url.Parse(resource)
+		if err != nil || !strings.EqualFold(u.Host, f.host()) || u.Path != "/ap/actor" {
+			return nil, domain.ErrActorNotFound
+		}
+		authorID, _ = strconv.ParseUint(u.Query().Get("id"), 10, 64)
+	}
+
+	author, err := f.author(uint(authorID))
+	if err != nil {
+		return nil, err
+	}
+	actor := f.ActorURL(author.ID)
+	return &activitypub.JRD{
+		Subject: "acct:" + handle(author.ID) + "@" + f.host(),
+		Aliases: []string{actor},
+		Links: []activitypub.JRDLink{
+			{Rel: "self", Type: activitypub.ContentType, Href: actor},
+		},
+	}, nil
+}
+
+// Actor returns the author's Person document.
+func (f *FederationUsecase) Actor(authorID uint) (*activitypub.Actor, error) {
+	author, err := f.author(authorID)
+	if err != nil {
+		return nil, err
+	}
+	key, err := f.actorKey(author.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	id := f.ActorURL(author.ID)
+	return &activitypub.Actor{
+		Context:           activitypub.Context,
+		ID:                id,
+		Type:              "Person",
+		PreferredUsername: handle(author.ID),
+		Name:              handle(author.ID),
+		Inbox:             f.endpoint("/ap/inbox", author.ID),
+		Outbox:            f.endpoint("/ap/outbox", author.ID),
+		Followers:         f.followersURL(author.ID),
+		PublicKey: &activitypub.PublicKey{
+			ID:           f.keyID(author.ID),
+			Owner:        id,
+			PublicKeyPem: key.PublicKeyPEM,
+		},
+	}, nil
+}
+
+// Article returns a public post of the default publication as an Article.
+func (f *FederationUsecase) Article(postID uint) (*activitypub.Article, error) {
+	post, err := f.blogRepo.FindByID(postID)
+	if err != nil {
+		return nil, err
+	}
+	if !f.federated(post) {
+		return nil, domain.ErrPostNotFound
+	}
+	article := f.article(post)
+	return &article, nil
+}
+
+// Outbox returns the author's outbox collection, or with page > 0 one page
+// of it, newest first.
+func (f *FederationUsecase) Outbox(authorID uint, page int) (*activitypub.OrderedCollection, error) {
+	author, err := f.author(authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := domain.PostFilter{
+		PublicationID: domain.DefaultPublicationID,
+		AuthorID:      author.ID,
+		Status:        domain.PostStatusPublished,
+		Visibilities:  []string{domain.VisibilityPublic},
+	}
+	total, err := f.blogRepo.Count(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	id := f.endpoint("/ap/outbox", author.ID)
+	if page <= 0 {
+		return &activitypub.OrderedCollection{
+			Context:    activitypub.Context,
+			ID:         id,
+			Type:       "OrderedCollection",
+			TotalItems: int(total),
+			First:      id + "&page=1",
+		}, nil
+	}
+
+	filter.Limit = outboxPageSize
+	filter.Offset = (page - 1) * outboxPageSize
+	posts, err := f.blogRepo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	coll := &activitypub.OrderedCollection{
+		Context:      activitypub.Context,
+		ID:           fmt.Sprintf("%s&page=%d", id, page),
+		Type:         "OrderedCollectionPage",
+		TotalItems:   int(total),
+		PartOf:       id,
+		OrderedItems: make([]any, 0, len(posts)),
+	}
+	if int64(page*outboxPageSize) < total {
+		coll.Next = fmt.Sprintf("%s&page=%d", id, page+1)
+	}
+	for _, p := range posts {
+		create, err := f.createActivity(p)
+		if err != nil {
+			return nil, err
+		}
+		coll.OrderedItems = append(coll.OrderedItems, create)
+	}
+	return coll, nil
+}
+
+// Followers returns the size of the author's followers collection; the
+// followers themselves are not disclosed.
+func (f *FederationUsecase) Followers(authorID uint) (*activitypub.OrderedCollection, error) {
+	author, err := f.author(authorID)
+	if err != nil {
+		return nil, err
+	}
+	count, err := f.fedRepo.CountFollowers(author.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &activitypub.OrderedCollection{
+		Context:    activitypub.Context,
+		ID:         f.followersURL(author.ID),
+		Type:       "OrderedCollection",
+		TotalItems: int(count),
+	}, nil
+}
+
+// INBOX
+
+// Inbox handles an activity POSTed to the author's inbox. The request must
+// be signed by the activity's actor. Follow and Undo{Follow} are acted on;
+// other activities are accepted and ignored.
+func (f *FederationUsecase) Inbox(authorID uint, r *http.Request, body []byte) error {
+	author, err := f.author(authorID)
+	if err != nil {
+		return err
+	}
+
+	var activity activitypub.Activity
+	if err := json.Unmarshal(body, &activity); err != nil {
+		return errors.New("invalid activity")
+	}
+	remote, err := f.verify(r, body)
+	if err != nil {
+		return err
+	}
+	if activity.Actor != remote.ID {
+		return domain.ErrUnauthorizedPost
+	}
+
+	switch activity.Type {
+	case "Follow":
+		if activity.ObjectID() != f.ActorURL(author.ID) {
+			return nil
+		}
+		return f.acceptFollow(author.ID, remote, &activity)
+	case "Undo":
+		inner, ok := activity.EmbeddedActivity()
+		if ok && inner.Type == "Follow" && inner.Actor == remote.ID {
+			return f.fedRepo.DeleteFollower(author.ID, remote.ID)
+		}
+	}
+	return nil
+}
+
+// verify checks the request's HTTP signature against the key of the actor
+// it names, and returns that actor. The key must be the actor's own: the
+// keyId is the actor's ID plus a fragment, and the actor's inboxes are on
+// the actor's host, so a signature can't speak for or deliver to another
+// server.
+func (f *FederationUsecase) verify(r *http.Request, body []byte) (*activitypub.Actor, error) {
+	keyID, err := activitypub.SignatureKeyID(r)
+	if err != nil {
+		return nil, err
+	}
+	actorURL, _, _ := strings.Cut(keyID, "#")
+	keyURL, err := url.Parse(actorURL)
+	if err != nil || (keyURL.Scheme != "https" && keyURL.Scheme != "http") || keyURL.Host == "" {
+		return nil, fmt.Errorf("%w: invalid key %s", activitypub.ErrInvalidSignature, keyID)
+	}
+
+	actor, err := f.client.FetchActor(actorURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", activitypub.ErrInvalidSignature, err)
+	}
+	if actor.ID != actorURL {
+		return nil, fmt.Errorf("%w: key %s is not %s's", activitypub.ErrInvalidSignature, keyID, actor.ID)
+	}
+	if actor.PublicKey == nil || actor.PublicKey.ID != keyID ||
+		(actor.PublicKey.Owner != "" && actor.PublicKey.Owner != actor.ID) {
+		return nil, fmt.Errorf("%w: unknown key %s", activitypub.ErrInvalidSignature, keyID)
+	}
+	inboxes := []string{actor.Inbox}
+	if actor.Endpoints != nil && actor.Endpoints.SharedInbox != "" {
+		inboxes = append(inboxes, actor.Endpoints.SharedInbox)
+	}
+	for _, inbox := range inboxes {
+		if u, err := url.Parse(inbox); err != nil || u.Host != keyURL.Host {
+			return nil, fmt.Errorf("%w: inbox %s is not on %s", activitypub.ErrInvalidSignature, inbox, keyURL.Host)
+		}
+	}
+	key, err := activitypub.ParsePublicKey(actor.PublicKey.PublicKeyPem)
+	if err != nil {
+		return nil, err
+	}
+	if err := activitypub.Verify(r, body, key); err != nil {
+		return nil, err
+	}
+	return actor, nil
+}
+
+func (f *FederationUsecase) acceptFollow(authorID uint, remote *activitypub.Actor, follow *activitypub.Activity) error {
+	inbox := remote.Inbox
+	if remote.Endpoints != nil && remote.Endpoints.SharedInbox != "" {
+		inbox = remote.Endpoints.SharedInbox
+	}
+	if err := f.fedRepo.SaveFollower(&domain.Follower{AuthorID: authorID, ActorID: remote.ID, Inbox: inbox}); err != nil {
+		return err
+	}
+
+	actor := f.ActorURL(authorID)
+	accept := activitypub.Activity{
+		Context: activitypub.Context,
+		ID:      actor + "#accepts/" + shortHash(follow.ID),
+		Type:    "Accept",
+		Actor:   actor,
+		To:      []string{remote.ID},
+	}
+	follow.Context = nil
+	if err := accept.SetObject(follow); err != nil {
+		return err
+	}
+	return f.enqueue(authorID, &accept, remote.Inbox)
+}
+
+// PUBLISHING
+
+// HandlePostEvent is the RabbitMQ handler for blog.published: it queues a
+// Create{Article} for every follower inbox of the post's author.
+func (f *FederationUsecase) HandlePostEvent(body []byte) error {
+	var e domain.PostEvent
+	if err := json.Unmarshal(body, &e); err != nil {
+		return err
+	}
+	if e.Type != domain.EventPostPublished || e.Post == nil || !f.federated(e.Post) {
+		return nil
+	}
+
+	followers, err := f.fedRepo.FindFollowers(e.Post.AuthorID)
+	if err != nil || len(followers) == 0 {
+		return err
+	}
+
+	create, err := f.createActivity(e.Post)
+	if err != nil {
+		return err
+	}
+	inboxes := make([]string, 0, len(followers))
+	for _, fl := range followers {
+		inboxes = append(inboxes, fl.Inbox)
+	}
+	return f.enqueue(e.Post.AuthorID, create, inboxes...)
+}
+
+// federated reports whether a post is sent to the fediverse.
+func (f *FederationUsecase) federated(post *domain.BlogPost) bool {
+	return post.PublicationID == domain.DefaultPublicationID && post.VisibleTo(domain.Viewer{}, false)
+}
+
+func (f *FederationUsecase) article(post *domain.BlogPost) activitypub.Article {
+	postURL := f.blogs.PostURL(post.Slug)
+	content := fmt.Sprintf("<p>%s</p><p><a href=\"%s\">%s</a></p>",
+		html.EscapeString(post.Description()), html.EscapeString(postURL), html.EscapeString(postURL))
+
+	article := activitypub.Article{
+		ID:           f.objectURL(post.ID),
+		Type:         "Article",
+		AttributedTo: f.ActorURL(post.AuthorID),
+		Name:         post.Title,
+		Summary:      post.Description(),
+		Content:      content,
+		URL:          postURL,
+		To:           []string{activitypub.Public},
+		CC:           []string{f.followersURL(post.AuthorID)},
+		Published:    post.PublishedAt,
+	}
+	if post.UpdatedAt.After(post.CreatedAt) {
+		updated := post.UpdatedAt
+		article.Updated = &updated
+	}
+	for _, t := range post.Tags {
+		article.Tag = append(article.Tag, activitypub.Hashtag{Type: "Hashtag", Name: "#" + t})
+	}
+	return article
+}
+
+func (f *FederationUsecase) createActivity(post *domain.BlogPost) (*activitypub.Activity, error) {
+	article := f.article(post)
+	create := &activitypub.Activity{
+		Context:   activitypub.Context,
+		ID:        article.ID + "#create",
+		Type:      "Create",
+		Actor:     article.AttributedTo,
+		To:        article.To,
+		CC:        article.CC,
+		Published: post.PublishedAt,
+	}
+	return create, create.SetObject(article)
+}
+
+// enqueue queues activity for delivery to each distinct inbox.
+func (f *FederationUsecase) enqueue(authorID uint, activity *activitypub.Activity, inboxes ...string) error {
+	payload, err := json.Marshal(activity)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	ds := make([]*domain.Delivery, 0, len(inboxes))
+	for _, inbox := range inboxes {
+		if seen[inbox] {
+			continue
+		}
+		seen[inbox] = true
+		ds = append(ds, &domain.Delivery{
+			Key:      shortHash(activity.ID + " " + inbox),
+			AuthorID: authorID,
+			Inbox:    inbox,
+			Payload:  string(payload),
+		})
+	}
+	return f.fedRepo.EnqueueDeliveries(ds...)
+}
+
+// DeliverPending POSTs the deliveries that are due, signed with their
+// author's key, and reschedules the ones that fail. It returns how many
+// were delivered.
+func (f *FederationUsecase) DeliverPending() (int, error) {
+	now := time.Now()
+	ds, err := f.fedRepo.FindDueDeliveries(now, deliveryBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	keys := make(map[uint]*rsa.PrivateKey)
+	delivered := 0
+	for _, d := range ds {
+		key, ok := keys[d.AuthorID]
+		if !ok {
+			if key, err = f.signingKey(d.AuthorID); err != nil {
+				return delivered, err
+			}
+			keys[d.AuthorID] = key
+		}
+
+		if err := f.client.Deliver(d.Inbox, []byte(d.Payload), f.keyID(d.AuthorID), key); err != nil {
+			retry := d.Failed(err, now)
+			if !retry {
+				log.Printf("activitypub: giving up delivery %d to %s: %v", d.ID, d.Inbox, err)
+			}
+			if err := f.fedRepo.SaveAttempt(d, retry); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+		if err := f.fedRepo.MarkDelivered(d.ID); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+	return delivered, nil
+}
+
+// KEYS
+
+// actorKey returns the author's key pair, creating it on first use.
+func (f *FederationUsecase) actorKey(authorID uint) (*domain.ActorKey, error) {
+	key, err := f.fedRepo.FindKey(authorID)
+	if err != nil || key != nil {
+		return key, err
+	}
+
+	private, public, err := activitypub.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := f.fedRepo.CreateKey(&domain.ActorKey{AuthorID: authorID, PublicKeyPEM: public, PrivateKeyPEM: private}); err != nil {
+		return nil, err
+	}
+	// Re-read in case a concurrent request stored its key first.
+	return f.fedRepo.FindKey(authorID)
+}
+
+func (f *FederationUsecase) signingKey(authorID uint) (*rsa.PrivateKey, error) {
+	key, err := f.actorKey(authorID)
+	if err != nil {
+		return nil, err
+	}
+	return activitypub.ParsePrivateKey(key.PrivateKeyPEM)
+}
+
+func (f *FederationUsecase) author(authorID uint) (*domain.Author, error) {
+	if authorID == 0 {
+		return nil, domain.ErrActorNotFound
+	}
+	author, err := f.authorRepo.FindByID(authorID)
+	if err != nil {
+		return nil, domain.ErrActorNotFound
+	}
+	return author, nil
+}
+
+func shortHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:16])
+}
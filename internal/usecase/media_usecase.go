@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"strconv"
+
+	"github.com/Hamiduzzaman96/Blog-Service/config"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
+)
+
+// MediaUsecase stores uploaded files and serves them back.
+type MediaUsecase struct {
+	mediaRepo *repository.MediaRepository
+	userRepo  *repository.UserRepository
+	cfg       config.BlogConfig
+}
+
+func NewMediaUsecase(mediaRepo *repository.MediaRepository, userRepo *repository.UserRepository, cfg config.BlogConfig) *MediaUsecase {
+	return &MediaUsecase{mediaRepo: mediaRepo, userRepo: userRepo, cfg: cfg}
+}
+
+// Upload stores a file of userID and returns it. The user's email must be
+// verified and their uploads must stay within domain.MediaQuota.
+func (m *MediaUsecase) Upload(userID uint, name string, data []byte) (*domain.Media, error) {
+	user, err := m.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.EmailVerified {
+		return nil, domain.ErrEmailNotVerified
+	}
+	media, err := domain.NewMedia(userID, name, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.mediaRepo.CreateWithinQuota(media, domain.MediaQuota); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// Get returns an uploaded file.
+func (m *MediaUsecase) Get(id uint) (*domain.Media, error) {
+	return m.mediaRepo.FindByID(id)
+}
+
+// URL is the public address of an uploaded file.
+func (m *MediaUsecase) URL(media *domain.Media) string {
+	return m.cfg.MediaURL + "?id=" + strconv.FormatUint(uint64(media.ID), 10)
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/payment"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/redis"
+)
+
+// membershipPeriod is how long a purchased tier lasts.
+const membershipPeriod = 30 * 24 * time.Hour
+
+var (
+	errNoPaymentProvider = errors.New("no payment provider is configured")
+	errCheckoutCompleted = errors.New("checkout already completed")
+)
+
+// MembershipUsecase issues the entitlements that give users a membership
+// tier, by admin grant or through the payment provider. payments is nil
+// when no provider is configured.
+type MembershipUsecase struct {
+	entitlementRepo *repository.EntitlementRepository
+	userRepo        *repository.UserRepository
+	payments        payment.Provider
+	sessions        *redis.Client
+}
+
+func NewMembershipUsecase(
+	entitlementRepo *repository.EntitlementRepository,
+	userRepo *repository.UserRepository,
+	payments payment.Provider,
+	sessions *redis.Client,
+) *MembershipUsecase {
+	return &MembershipUsecase{
+		entitlementRepo: entitlementRepo,
+		userRepo:        userRepo,
+		payments:        payments,
+		sessions:        sessions,
+	}
+}
+
+// Tier returns the user's current membership tier.
+func (m *MembershipUsecase) Tier(userID uint) (string, error) {
+	entitlements, err := m.entitlementRepo.FindByUserID(userID)
+	if err != nil {
+		return "", err
+	}
+	return domain.HighestTier(entitlements), nil
+}
+
+// Grant lets an admin give a user a tier, until expiresAt if set.
+func (m *MembershipUsecase) Grant(admin domain.Viewer, userID uint, tier string, expiresAt *time.Time) (*domain.Entitlement, error) {
+	if !admin.Can(domain.PermMembershipManage) {
+		return nil, domain.ErrPermissionDenied
+	}
+	if _, err := m.userRepo.FindByID(userID); err != nil {
+		return nil, err
+	}
+
+	e, err := domain.NewEntitlement(userID, tier, domain.EntitlementSourceAdmin, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.entitlementRepo.Create(e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Revoke lets an admin withdraw an entitlement. The tier is carried in the
+// user's tokens, so the user is logged out everywhere.
+func (m *MembershipUsecase) Revoke(admin domain.Viewer, entitlementID uint) error {
+	if !admin.Can(domain.PermMembershipManage) {
+		return domain.ErrPermissionDenied
+	}
+	e, err := m.entitlementRepo.FindByID(entitlementID)
+	if err != nil {
+		return err
+	}
+	if err := m.entitlementRepo.Delete(entitlementID); err != nil {
+		return err
+	}
+	return m.sessions.RevokeUserSessions(e.UserID)
+}
+
+// Entitlements lists a user's entitlements to that user or an admin.
+func (m *MembershipUsecase) Entitlements(viewer domain.Viewer, userID uint) ([]*domain.Entitlement, error) {
+	if viewer.UserID != userID && !viewer.Can(domain.PermMembershipManage) {
+		return nil, domain.ErrPermissionDenied
+	}
+	return m.entitlementRepo.FindByUserID(userID)
+}
+
+// StartCheckout begins the purchase of a tier.
+func (m *MembershipUsecase) StartCheckout(userID uint, tier string) (*payment.Checkout, error) {
+	if !domain.ValidTier(tier) || tier == domain.TierFree {
+		return nil, domain.ErrInvalidTier
+	}
+	if m.payments == nil {
+		return nil, errNoPaymentProvider
+	}
+	return m.payments.CreateCheckout(userID, tier)
+}
+
+// CompleteCheckout grants the tier bought in a paid checkout session.
+func (m *MembershipUsecase) CompleteCheckout(userID uint, sessionID string) (*domain.Entitlement, error) {
+	if m.payments == nil {
+		return nil, errNoPaymentProvider
+	}
+	c, err := m.payments.GetCheckout(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if c.UserID != userID {
+		return nil, payment.ErrUnknownCheckout
+	}
+	if !c.Paid {
+		return nil, payment.ErrNotPaid
+	}
+
+	expiresAt := time.Now().Add(membershipPeriod)
+	e, err := domain.NewEntitlement(userID, c.Tier, domain.EntitlementSourcePayment, &expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	// The session is the reference, so completing it twice, even
+	// concurrently, grants the tier once.
+	e.Reference = c.SessionID
+	err = m.entitlementRepo.Create(e)
+	if errors.Is(err, domain.ErrEntitlementExists) {
+		return nil, errCheckoutCompleted
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
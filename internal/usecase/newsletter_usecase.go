@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/config"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/redis"
+)
+
+// Limits on subscription requests, which send email to any address.
+const (
+	subscribesPerIP      = 20
+	subscribesPerAddress = 3
+	subscribeWindow      = time.Hour
+)
+
+// NewsletterUsecase manages email-only subscriptions and mails new posts
+// to them.
+type NewsletterUsecase struct {
+	subscriberRepo *repository.SubscriberRepository
+	outboxRepo     *repository.EmailOutboxRepository
+	authorRepo     *repository.AuthorRepository
+	throttle       *redis.Client
+	cfg            config.NewsletterConfig
+	blogCfg        config.BlogConfig
+}
+
+func NewNewsletterUsecase(
+	subscriberRepo *repository.SubscriberRepository,
+	outboxRepo *repository.EmailOutboxRepository,
+	authorRepo *repository.AuthorRepository,
+	throttle *redis.Client,
+	cfg config.NewsletterConfig,
+	blogCfg config.BlogConfig,
+) *NewsletterUsecase {
+	return &NewsletterUsecase{
+		subscriberRepo: subscriberRepo,
+		outboxRepo:     outboxRepo,
+		authorRepo:     authorRepo,
+		throttle:       throttle,
+		cfg:            cfg,
+		blogCfg:        blogCfg,
+	}
+}
+
+// Subscribe starts a subscription of email to all posts (authorID 0) or to
+// one author, and mails a confirmation link. While a link sent earlier still
+// works no new one is sent, and an already confirmed subscription is left
+// alone, without telling the caller so that subscriptions cannot be probed.
+// Requests from ip beyond the limit fail with ErrSubscribeThrottled; those
+// for one address are dropped silently.
+func (n *NewsletterUsecase) Subscribe(email string, authorID uint, ip string) error {
+	sub, err := domain.NewSubscriber(email, authorID)
+	if err != nil {
+		return err
+	}
+
+	ok, err := n.throttle.Allow("newsletter:subscribe:ip:"+ip, subscribesPerIP, subscribeWindow)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrSubscribeThrottled
+	}
+	if authorID != 0 {
+		if _, err := n.authorRepo.FindByID(authorID); err != nil {
+			return err
+		}
+	}
+	ok, err = n.throttle.Allow("newsletter:subscribe:email:"+sub.Email, subscribesPerAddress, subscribeWindow)
+	if err != nil || !ok {
+		return err
+	}
+
+	existing, err := n.subscriberRepo.Find(sub.Email, authorID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if existing.Confirmed || existing.TokenPending() {
+			return nil
+		}
+		sub = existing
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return err
+	}
+	sub.SetToken(hashToken(token))
+	if err := n.subscriberRepo.Save(sub); err != nil {
+		return err
+	}
+
+	link := n.link("/newsletter/confirm", url.Values{"token": {token}})
+	return n.outboxRepo.Enqueue(&domain.OutboundEmail{
+		Key:     "newsletter-confirm:" + sub.TokenHash,
+		To:      sub.Email,
+		Subject: "Confirm your subscription to " + n.blogCfg.SiteName,
+		Body: fmt.Sprintf(
+			"Please confirm that you want to receive new posts from %s:\n\n%s\n\nIf you did not ask for this, ignore this email.\n",
+			n.blogCfg.SiteName, link,
+		),
+	})
+}
+
+// Confirm activates the subscription a confirmation link was sent for,
+// unless the link has expired.
+func (n *NewsletterUsecase) Confirm(token string) error {
+	sub, err := n.subscriberRepo.FindByTokenHash(hashToken(token))
+	if err != nil {
+		return err
+	}
+	if !sub.TokenPending() {
+		return domain.ErrInvalidConfirmToken
+	}
+	sub.Confirm()
+	return n.subscriberRepo.Save(sub)
+}
+
+// CheckUnsubscribe verifies the signature of an unsubscribe link without
+// acting on it.
+func (n *NewsletterUsecase) CheckUnsubscribe(id uint, signature string) error {
+	if !hmac.Equal([]byte(signature), []byte(n.sign(id))) {
+		return domain.ErrInvalidUnsubscribe
+	}
+	return nil
+}
+
+// Unsubscribe ends the subscription named in a signed unsubscribe link.
+func (n *NewsletterUsecase) Unsubscribe(id uint, signature string) error {
+	if err := n.CheckUnsubscribe(id, signature); err != nil {
+		return err
+	}
+	return n.subscriberRepo.Delete(id)
+}
+
+// HandlePostEvent is the RabbitMQ handler for blog.published: it enqueues
+// the post to every confirmed subscriber of all posts or of its author.
+// Only posts anyone may read are mailed.
+func (n *NewsletterUsecase) HandlePostEvent(body []byte) error {
+	var e domain.PostEvent
+	if err := json.Unmarshal(body, &e); err != nil {
+		return err
+	}
+	if e.Type != domain.EventPostPublished || e.Post == nil || !e.Post.VisibleTo(domain.Viewer{}, false) {
+		return nil
+	}
+	if e.PublicationID != domain.DefaultPublicationID {
+		// Subscriptions are to the default publication's posts.
+		return nil
+	}
+
+	subs, err := n.subscriberRepo.FindConfirmedFor(e.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	postURL := strings.TrimRight(n.blogCfg.PublicURL, "/") + "/posts/" + e.Post.Slug
+	emails := make([]*domain.OutboundEmail, 0, len(subs))
+	seen := make(map[string]bool)
+	for _, sub := range subs {
+		// Someone subscribed to both all posts and the author gets one email.
+		if seen[sub.Email] {
+			continue
+		}
+		seen[sub.Email] = true
+
+		unsubscribe := n.unsubscribeURL(sub.ID)
+		emails = append(emails, &domain.OutboundEmail{
+			Key:     fmt.Sprintf("newsletter-post:%d:%s", e.PostID, sub.Email),
+			To:      sub.Email,
+			Subject: e.Post.Title,
+			Body: fmt.Sprintf(
+				"%s\n\n%s\n\nRead more: %s\n\n--\nUnsubscribe: %s\n",
+				e.Post.Title, e.Post.Description(), postURL, unsubscribe,
+			),
+			ListUnsubscribe: unsubscribe,
+		})
+	}
+	return n.outboxRepo.Enqueue(emails...)
+}
+
+func (n *NewsletterUsecase) unsubscribeURL(id uint) string {
+	return n.link("/newsletter/unsubscribe", url.Values{
+		"id":  {strconv.FormatUint(uint64(id), 10)},
+		"sig": {n.sign(id)},
+	})
+}
+
+// sign returns the HMAC authorising the unsubscribe link of subscription id.
+func (n *NewsletterUsecase) sign(id uint) string {
+	mac := hmac.New(sha256.New, []byte(n.cfg.Secret))
+	fmt.Fprintf(mac, "unsubscribe:%d", id)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (n *NewsletterUsecase) link(path string, q url.Values) string {
+	return strings.TrimRight(n.cfg.PublicURL, "/") + path + "?" + q.Encode()
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
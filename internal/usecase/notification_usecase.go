@@ -1,16 +1,23 @@
 package usecase
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
 )
 
 type NotificationUsecase struct {
 	notificationRepo *repository.NotificationRepository
+	outboxRepo       *repository.EmailOutboxRepository
 }
 
-func NewNotificationUsecase(notificatioRepo *repository.NotificationRepository) *NotificationUsecase {
-	return &NotificationUsecase{notificationRepo: notificatioRepo}
+func NewNotificationUsecase(
+	notificatioRepo *repository.NotificationRepository,
+	outboxRepo *repository.EmailOutboxRepository,
+) *NotificationUsecase {
+	return &NotificationUsecase{notificationRepo: notificatioRepo, outboxRepo: outboxRepo}
 }
 
 func (n *NotificationUsecase) Send(userID uint, message string) error {
@@ -23,3 +30,47 @@ func (n *NotificationUsecase) Send(userID uint, message string) error {
 	_, err := n.notificationRepo.Create(notification)
 	return err
 }
+
+// Email queues an email in the outbox. key makes it idempotent: an email
+// whose key is already queued is not queued again.
+func (n *NotificationUsecase) Email(key, to, subject, body string) error {
+	return n.outboxRepo.Enqueue(&domain.OutboundEmail{
+		Key:     key,
+		To:      to,
+		Subject: subject,
+		Body:    body,
+	})
+}
+
+// HandlePostEvent is the RabbitMQ handler telling authors about review
+// decisions and publications of their posts.
+func (n *NotificationUsecase) HandlePostEvent(body []byte) error {
+	var e domain.PostEvent
+	if err := json.Unmarshal(body, &e); err != nil {
+		return err
+	}
+	if e.AuthorUserID == 0 || e.Post == nil {
+		return nil
+	}
+
+	var message string
+	switch e.Type {
+	case domain.EventReviewSubmitted:
+		message = fmt.Sprintf("%q was submitted for review", e.Post.Title)
+	case domain.EventReviewApproved:
+		message = fmt.Sprintf("%q was approved and can be published", e.Post.Title)
+	case domain.EventReviewChangesRequested:
+		message = fmt.Sprintf("An editor requested changes to %q (%d comments)", e.Post.Title, len(e.Comments))
+	case domain.EventReviewRejected:
+		message = fmt.Sprintf("%q was rejected", e.Post.Title)
+	case domain.EventPostPublished:
+		message = fmt.Sprintf("%q is now published", e.Post.Title)
+	default:
+		return nil
+	}
+	if e.Note != "" {
+		message += ": " + e.Note
+	}
+
+	return n.Send(e.AuthorUserID, message)
+}
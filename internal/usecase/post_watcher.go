@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
+)
+
+const (
+	// watchBuffer is how many live events a subscriber may lag behind
+	// before it is disconnected and has to resume.
+	watchBuffer = 256
+	// replayPage is the number of stored events loaded per query on resume.
+	replayPage = 500
+)
+
+// ErrWatchTooSlow ends a watch whose subscriber could not keep up. The
+// client reconnects with its last resume token.
+var ErrWatchTooSlow = errors.New("subscriber fell behind, resume from the last token")
+
+// PostWatcher fans post events received from RabbitMQ out to WatchPosts
+// subscribers. Resuming subscribers first replay the stored event log.
+type PostWatcher struct {
+	eventRepo  *repository.PostEventRepository
+	authorRepo *repository.AuthorRepository
+
+	mu   sync.Mutex
+	subs map[chan *domain.PostEvent]struct{}
+}
+
+func NewPostWatcher(
+	eventRepo *repository.PostEventRepository,
+	authorRepo *repository.AuthorRepository,
+) *PostWatcher {
+	return &PostWatcher{
+		eventRepo:  eventRepo,
+		authorRepo: authorRepo,
+		subs:       make(map[chan *domain.PostEvent]struct{}),
+	}
+}
+
+// Dispatch is the RabbitMQ handler for post events.
+func (w *PostWatcher) Dispatch(body []byte) error {
+	var e domain.PostEvent
+	if err := json.Unmarshal(body, &e); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for ch := range w.subs {
+		select {
+		case ch <- &e:
+		default:
+			// Too slow: drop the subscriber rather than block everyone else.
+			delete(w.subs, ch)
+			close(ch)
+		}
+	}
+	return nil
+}
+
+// Watch calls send for every event matching filter that the viewer may see,
+// until ctx is done or send fails. With a non-zero resumeAfter, stored events
+// after that sequence number are replayed first, so nothing is missed
+// between two connections.
+func (w *PostWatcher) Watch(
+	ctx context.Context,
+	viewer domain.Viewer,
+	filter domain.PostEventFilter,
+	resumeAfter uint64,
+	send func(*domain.PostEvent) error,
+) error {
+	if viewer.LoggedIn() {
+		if author, err := w.authorRepo.FindByUserID(viewer.UserID); err == nil {
+			viewer.AuthorID = author.ID
+		}
+	}
+
+	deliver := func(e *domain.PostEvent) error {
+		if !filter.Matches(e) {
+			return nil
+		}
+		if !e.VisibleTo(viewer) {
+			return nil
+		}
+		if e.Post != nil && e.Post.Paywalled(viewer) {
+			view := &domain.PostView{BlogPost: e.Post}
+			view.ApplyPaywall(viewer)
+			copied := *e
+			copied.Post = view.BlogPost
+			e = &copied
+		}
+		return send(e)
+	}
+
+	// last is the sequence number of the newest event delivered.
+	last := resumeAfter
+	replay := func() error {
+		for {
+			events, err := w.eventRepo.FindAfter(last, replayPage)
+			if err != nil {
+				return err
+			}
+			for _, e := range events {
+				if err := deliver(e); err != nil {
+					return err
+				}
+				last = e.ID
+			}
+			if len(events) < replayPage {
+				return nil
+			}
+		}
+	}
+
+	// Catch up with the log page by page before subscribing, so that a
+	// long replay can't overflow the live buffer. After subscribing, replay
+	// once more for the events stored in between; live events that were
+	// replayed are skipped by their sequence number.
+	if resumeAfter != 0 {
+		if err := replay(); err != nil {
+			return err
+		}
+	}
+
+	ch := make(chan *domain.PostEvent, watchBuffer)
+	w.mu.Lock()
+	w.subs[ch] = struct{}{}
+	w.mu.Unlock()
+	defer w.unsubscribe(ch)
+
+	if resumeAfter != 0 {
+		if err := replay(); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-ch:
+			if !ok {
+				return ErrWatchTooSlow
+			}
+			if e.ID <= last {
+				continue
+			}
+			if err := deliver(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (w *PostWatcher) unsubscribe(ch chan *domain.PostEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.subs[ch]; ok {
+		delete(w.subs, ch)
+		close(ch)
+	}
+}
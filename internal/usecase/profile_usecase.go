@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/Hamiduzzaman96/Blog-Service/config"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
+)
+
+// ProfileUsecase reads users and manages their public profiles.
+type ProfileUsecase struct {
+	userRepo  *repository.UserRepository
+	mediaRepo *repository.MediaRepository
+	cfg       config.BlogConfig
+}
+
+func NewProfileUsecase(
+	userRepo *repository.UserRepository,
+	mediaRepo *repository.MediaRepository,
+	cfg config.BlogConfig,
+) *ProfileUsecase {
+	return &ProfileUsecase{userRepo: userRepo, mediaRepo: mediaRepo, cfg: cfg}
+}
+
+// GetByID returns a user.
+func (p *ProfileUsecase) GetByID(userID uint) (*domain.User, error) {
+	return p.userRepo.FindByID(userID)
+}
+
+// UpdateProfile applies changes to the user's profile. The avatar must be
+// an image the user uploaded.
+func (p *ProfileUsecase) UpdateProfile(userID uint, changes domain.ProfileChanges) (*domain.User, error) {
+	user, err := p.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := changes.Apply(&user.Profile); err != nil {
+		return nil, err
+	}
+
+	if changes.AvatarMediaID != nil && *changes.AvatarMediaID != 0 {
+		media, err := p.mediaRepo.FindByID(*changes.AvatarMediaID)
+		if errors.Is(err, domain.ErrMediaNotFound) {
+			return nil, domain.ErrInvalidAvatar
+		}
+		if err != nil {
+			return nil, err
+		}
+		if media.UserID != userID || !strings.HasPrefix(media.ContentType, "image/") {
+			return nil, domain.ErrInvalidAvatar
+		}
+	}
+
+	if err := p.userRepo.UpdateProfile(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// AvatarURL is the address of the user's avatar, or "" if they have none.
+func (p *ProfileUsecase) AvatarURL(profile domain.UserProfile) string {
+	if profile.AvatarMediaID == 0 {
+		return ""
+	}
+	return p.cfg.MediaURL + "?id=" + strconv.FormatUint(uint64(profile.AvatarMediaID), 10)
+}
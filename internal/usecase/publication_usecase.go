@@ -0,0 +1,298 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/config"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/i18n"
+)
+
+// PublicationUsecase manages the team publications hosted next to the
+// default blog, and finds the publication a request is for.
+type PublicationUsecase struct {
+	pubRepo  *repository.PublicationRepository
+	reserved []string // hosts of the deployment's own URLs
+}
+
+func NewPublicationUsecase(pubRepo *repository.PublicationRepository, cfg config.BlogConfig) *PublicationUsecase {
+	var reserved []string
+	for _, raw := range []string{cfg.PublicURL, cfg.OEmbedURL, cfg.WebmentionURL, cfg.MediaURL} {
+		if u, err := url.Parse(raw); err == nil && u.Host != "" {
+			reserved = append(reserved, domain.NormalizeHost(u.Host))
+		}
+	}
+	return &PublicationUsecase{pubRepo: pubRepo, reserved: reserved}
+}
+
+// domainLookupTimeout bounds the DNS lookup of a domain verification.
+const domainLookupTimeout = 5 * time.Second
+
+// Resolve returns the publication a request is addressed to: the one with
+// explicitID if given, else the one served on host, else nil for the
+// default publication.
+func (p *PublicationUsecase) Resolve(host string, explicitID uint) (*domain.Publication, error) {
+	if explicitID != domain.DefaultPublicationID {
+		return p.pubRepo.FindByID(explicitID)
+	}
+
+	host = domain.NormalizeHost(host)
+	if host == "" {
+		return nil, nil
+	}
+	pub, err := p.pubRepo.FindByDomain(host)
+	if errors.Is(err, domain.ErrPublicationNotFound) {
+		return nil, nil
+	}
+	return pub, err
+}
+
+// Create starts a publication owned by the calling user.
+func (p *PublicationUsecase) Create(ownerUserID uint, name, slug, customDomain string) (*domain.Publication, error) {
+	pub, err := domain.NewPublication(name, slug, customDomain)
+	if err != nil {
+		return nil, err
+	}
+	base := pub.Slug
+	for i := 2; ; i++ {
+		exists, err := p.pubRepo.SlugExists(pub.Slug)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			break
+		}
+		pub.Slug = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	if err := p.claimDomain(pub, pub.PendingDomain); err != nil {
+		return nil, err
+	}
+	if err := p.pubRepo.Create(pub, ownerUserID); err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+func (p *PublicationUsecase) Get(id uint) (*domain.Publication, error) {
+	return p.pubRepo.FindByID(id)
+}
+
+// PublicationChanges are the owner-editable fields of a publication. Nil
+// fields are left unchanged.
+type PublicationChanges struct {
+	Name         *string
+	Description  *string
+	CustomDomain *string
+	Settings     *domain.PublicationSettings
+}
+
+// Update changes a publication's details and settings. Owners only.
+func (p *PublicationUsecase) Update(userID, id uint, changes PublicationChanges) (*domain.Publication, error) {
+	pub, err := p.ownedPublication(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if changes.Name != nil {
+		if strings.TrimSpace(*changes.Name) == "" {
+			return nil, errors.New("publication name cannot be empty")
+		}
+		pub.Name = strings.TrimSpace(*changes.Name)
+	}
+	if changes.Description != nil {
+		pub.Description = *changes.Description
+	}
+	if changes.CustomDomain != nil {
+		if err := p.claimDomain(pub, *changes.CustomDomain); err != nil {
+			return nil, err
+		}
+	}
+	if changes.Settings != nil {
+		settings := *changes.Settings
+		if settings.DefaultLocale != "" {
+			locale, err := i18n.Normalize(settings.DefaultLocale)
+			if err != nil {
+				return nil, errors.New("invalid locale")
+			}
+			settings.DefaultLocale = locale
+		}
+		pub.Settings = settings
+	}
+
+	if err := p.pubRepo.Update(pub); err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+// claimDomain starts moving pub to the custom domain host. The domain
+// stays pending until VerifyDomain finds the publication's token in its
+// DNS; an empty host takes the publication off its custom domain.
+func (p *PublicationUsecase) claimDomain(pub *domain.Publication, host string) error {
+	host = domain.NormalizeHost(host)
+	pub.PendingDomain, pub.DomainToken = "", ""
+	if host == "" {
+		pub.CustomDomain = ""
+		return nil
+	}
+	if host == pub.CustomDomain {
+		return nil
+	}
+	if err := p.checkDomain(pub, host); err != nil {
+		return err
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return err
+	}
+	pub.PendingDomain, pub.DomainToken = host, token
+	return nil
+}
+
+// checkDomain rejects hosts that aren't DNS names, that belong to the
+// deployment itself, or that overlap a domain another publication serves.
+func (p *PublicationUsecase) checkDomain(pub *domain.Publication, host string) error {
+	if !domain.ValidDomain(host) {
+		return domain.ErrInvalidDomain
+	}
+	for _, reserved := range p.reserved {
+		if domain.DomainsOverlap(host, reserved) {
+			return domain.ErrDomainTaken
+		}
+	}
+	taken, err := p.pubRepo.DomainOverlaps(host, pub.ID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return domain.ErrDomainTaken
+	}
+	return nil
+}
+
+// VerifyDomain activates a publication's pending custom domain once its
+// DNS TXT record _blog-verification.{domain} holds the publication's
+// token. Owners only.
+func (p *PublicationUsecase) VerifyDomain(userID, id uint) (*domain.Publication, error) {
+	pub, err := p.ownedPublication(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if pub.PendingDomain == "" {
+		return nil, errors.New("no custom domain is pending verification")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), domainLookupTimeout)
+	defer cancel()
+	records, err := net.DefaultResolver.LookupTXT(ctx, domain.DomainVerificationPrefix+pub.PendingDomain)
+	if err != nil {
+		return nil, domain.ErrDomainNotVerified
+	}
+	verified := false
+	for _, r := range records {
+		if strings.TrimSpace(r) == pub.DomainToken {
+			verified = true
+		}
+	}
+	if !verified {
+		return nil, domain.ErrDomainNotVerified
+	}
+
+	// Another publication may have gone live on the domain meanwhile.
+	if err := p.checkDomain(pub, pub.PendingDomain); err != nil {
+		return nil, err
+	}
+	pub.CustomDomain = pub.PendingDomain
+	pub.PendingDomain, pub.DomainToken = "", ""
+	if err := p.pubRepo.Update(pub); err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+// Members lists a publication's members to one of them.
+func (p *PublicationUsecase) Members(userID, id uint) ([]*domain.PublicationMember, error) {
+	if _, err := p.pubRepo.FindMember(id, userID); err != nil {
+		return nil, err
+	}
+	return p.pubRepo.ListMembers(id)
+}
+
+// SetMember adds a user to a publication or changes their role. Owners only.
+func (p *PublicationUsecase) SetMember(ownerUserID, id, userID uint, role string) (*domain.PublicationMember, error) {
+	if !domain.ValidPublicationRole(role) {
+		return nil, errors.New("invalid publication role")
+	}
+	if _, err := p.ownedPublication(ownerUserID, id); err != nil {
+		return nil, err
+	}
+	if role != domain.PublicationOwner {
+		if err := p.keepAnOwner(id, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	member := &domain.PublicationMember{PublicationID: id, UserID: userID, Role: role}
+	if err := p.pubRepo.SaveMember(member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember takes a user out of a publication. Owners may remove anyone,
+// other members only themselves.
+func (p *PublicationUsecase) RemoveMember(callerUserID, id, userID uint) error {
+	if callerUserID != userID {
+		if _, err := p.ownedPublication(callerUserID, id); err != nil {
+			return err
+		}
+	}
+	if err := p.keepAnOwner(id, userID); err != nil {
+		return err
+	}
+	return p.pubRepo.DeleteMember(id, userID)
+}
+
+// keepAnOwner refuses to demote or remove a publication's last owner.
+func (p *PublicationUsecase) keepAnOwner(id, userID uint) error {
+	member, err := p.pubRepo.FindMember(id, userID)
+	if errors.Is(err, domain.ErrNotMember) {
+		return nil
+	}
+	if err != nil || member.Role != domain.PublicationOwner {
+		return err
+	}
+
+	owners, err := p.pubRepo.CountOwners(id)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return errors.New("a publication must keep at least one owner")
+	}
+	return nil
+}
+
+func (p *PublicationUsecase) ownedPublication(userID, id uint) (*domain.Publication, error) {
+	pub, err := p.pubRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	member, err := p.pubRepo.FindMember(id, userID)
+	if errors.Is(err, domain.ErrNotMember) || (err == nil && member.Role != domain.PublicationOwner) {
+		return nil, domain.ErrNotPublicationOwner
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
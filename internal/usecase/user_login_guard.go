@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+)
+
+// maxLoginBackoff caps the wait between failed logins before a lockout.
+const maxLoginBackoff = time.Minute
+
+// noUserHash is compared against when a login names an unknown email, so
+// that it takes as long as a login with a wrong password.
+const noUserHash = "$2a$10$aIBRXnSDWQAo/XXI2Ghff..EAwqc0MJMAMuV4eW0m0K5RzIlvcy6y"
+
+// loginKeys returns the keys failed logins are counted under: the account
+// and the client address. Unknown emails are counted too, so that lockouts
+// don't reveal which accounts exist.
+func loginKeys(email, ip string) (account, client string) {
+	account = "account:" + strings.ToLower(strings.TrimSpace(email))
+	if ip != "" {
+		client = "ip:" + ip
+	}
+	return account, client
+}
+
+// checkLoginBlocked returns a *domain.LoginThrottledError while either key
+// is held back.
+func (u *UserUsecase) checkLoginBlocked(keys ...string) error {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		wait, err := u.redis.LoginBlocked(key)
+		if err != nil {
+			return err
+		}
+		if wait > 0 {
+			return &domain.LoginThrottledError{RetryAfter: wait}
+		}
+	}
+	return nil
+}
+
+// loginFailed counts a failed login and holds the key back: for a doubling
+// delay from LoginBackoffAfter failures on, and for LoginLockoutMin once
+// they reach lockoutAfter. It reports whether the key was just locked out.
+func (u *UserUsecase) loginFailed(key string, lockoutAfter int) (bool, error) {
+	window := time.Duration(u.cfg.LoginWindowMin) * time.Minute
+	failures, err := u.redis.RecordLoginFailure(key, window)
+	if err != nil {
+		return false, err
+	}
+
+	n := int(failures)
+	switch {
+	case n >= lockoutAfter:
+		lockout := time.Duration(u.cfg.LoginLockoutMin) * time.Minute
+		return n == lockoutAfter, u.redis.BlockLogin(key, lockout)
+	case n >= u.cfg.LoginBackoffAfter:
+		backoff := time.Second << (n - u.cfg.LoginBackoffAfter)
+		if backoff > maxLoginBackoff {
+			backoff = maxLoginBackoff
+		}
+		return false, u.redis.BlockLogin(key, backoff)
+	}
+	return false, nil
+}
+
+// recordLoginFailure counts a failed login of the account and the client
+// address, telling the owner of an existing account when it gets locked.
+func (u *UserUsecase) recordLoginFailure(user *domain.User, account, client string) {
+	locked, err := u.loginFailed(account, u.cfg.LoginLockoutAfter)
+	if err != nil {
+		log.Printf("failed to record failed login: %v", err)
+	}
+	if client != "" {
+		if _, err := u.loginFailed(client, u.cfg.LoginIPLockoutAfter); err != nil {
+			log.Printf("failed to record failed login: %v", err)
+		}
+	}
+
+	if locked && user != nil {
+		if err := u.notifyLockout(user); err != nil {
+			log.Printf("lockout notice to user %d failed: %v", user.ID, err)
+		}
+	}
+}
+
+func (u *UserUsecase) notifyLockout(user *domain.User) error {
+	body := fmt.Sprintf("There were %d failed attempts to log in to your account, so logins are "+
+		"blocked for %d minutes.\n\nIf it wasn't you, someone may be guessing your password; "+
+		"consider choosing a stronger one.\n", u.cfg.LoginLockoutAfter, u.cfg.LoginLockoutMin)
+	key := fmt.Sprintf("login-lockout:%d:%d", user.ID, time.Now().Unix())
+	if err := u.notifications.Email(key, user.Email, "Your account was locked", body); err != nil {
+		return err
+	}
+	return u.notifications.Send(user.ID, "Logins to your account were blocked after repeated failed attempts.")
+}
+
+// UnlockLogin lets an admin lift the failed-login block of an account
+// (by email), of a client address, or both.
+func (u *UserUsecase) UnlockLogin(admin domain.Viewer, email, ip string) error {
+	if !admin.Can(domain.PermUserUnlock) {
+		return domain.ErrPermissionDenied
+	}
+	if email == "" && ip == "" {
+		return errors.New("email or ip is required")
+	}
+
+	account, client := loginKeys(email, ip)
+	if email != "" {
+		if err := u.redis.ClearLoginFailures(account); err != nil {
+			return err
+		}
+	}
+	if client != "" {
+		return u.redis.ClearLoginFailures(client)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/totp"
+)
+
+const (
+	// mfaTokenPurpose namespaces login challenges in Redis.
+	mfaTokenPurpose = "mfa"
+	// mfaChallengeTTL is how long a login may wait for its second factor.
+	mfaChallengeTTL = 5 * time.Minute
+	// totpSkew accepts codes of one step before or after the current one.
+	totpSkew = 1
+)
+
+// EnrollTOTP starts setting up an authenticator for the user and returns
+// its secret and otpauth:// URI. It takes effect once ConfirmTOTP gets a
+// code from it; enrolling again before that starts over.
+func (u *UserUsecase) EnrollTOTP(userID uint) (string, string, error) {
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return "", "", err
+	}
+	existing, err := u.mfaRepo.FindTOTP(userID)
+	if err != nil {
+		return "", "", err
+	}
+	if existing != nil && existing.Enabled {
+		return "", "", domain.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return "", "", err
+	}
+	if err := u.mfaRepo.SavePendingTOTP(&domain.TOTPEnrollment{UserID: userID, Secret: secret}); err != nil {
+		return "", "", err
+	}
+	return secret, totp.URI(u.cfg.TOTPIssuer, user.Email, secret), nil
+}
+
+// ConfirmTOTP enables two-factor authentication with a first code from the
+// enrolled authenticator and returns the recovery codes, which are shown
+// once and stored hashed.
+func (u *UserUsecase) ConfirmTOTP(userID uint, code string) ([]string, error) {
+	enrollment, err := u.mfaRepo.FindTOTP(userID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment == nil {
+		return nil, domain.ErrMFANotEnrolled
+	}
+	if enrollment.Enabled {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+	step, ok := totp.Validate(enrollment.Secret, code, time.Now(), totpSkew)
+	if !ok {
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	codes := make([]string, domain.RecoveryCodeCount)
+	hashes := make([]string, domain.RecoveryCodeCount)
+	for i := range codes {
+		if codes[i], err = newRecoveryCode(); err != nil {
+			return nil, err
+		}
+		hashes[i] = hashToken(normalizeRecoveryCode(codes[i]))
+	}
+	if err := u.mfaRepo.EnableTOTP(userID, step, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTOTP turns two-factor authentication off, given a current code or
+// a recovery code.
+func (u *UserUsecase) DisableTOTP(userID uint, code string) error {
+	enrollment, err := u.mfaRepo.FindTOTP(userID)
+	if err != nil {
+		return err
+	}
+	if enrollment == nil || !enrollment.Enabled {
+		return domain.ErrMFANotEnrolled
+	}
+	if err := u.checkSecondFactor(enrollment, code); err != nil {
+		return err
+	}
+	return u.mfaRepo.DeleteTOTP(userID)
+}
+
+// LoginMFA finishes a login with the MFA token Login returned and a code
+// from the authenticator or a recovery code. The MFA token works once; a
+// wrong code counts as a failed login.
+func (u *UserUsecase) LoginMFA(mfaToken, code, ip string) (*domain.TokenPair, error) {
+	userID, ok, err := u.redis.TakeOneTimeToken(mfaTokenPurpose, hashToken(mfaToken))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, domain.ErrInvalidMFAChallenge
+	}
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, domain.ErrInvalidMFAChallenge
+	}
+
+	account, client := loginKeys(user.Email, ip)
+	if err := u.checkLoginBlocked(account, client); err != nil {
+		return nil, err
+	}
+
+	enrollment, err := u.mfaRepo.FindTOTP(userID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment == nil || !enrollment.Enabled {
+		return nil, domain.ErrInvalidMFAChallenge
+	}
+	if err := u.checkSecondFactor(enrollment, code); err != nil {
+		u.recordLoginFailure(user, account, client)
+		return nil, err
+	}
+
+	if err := u.redis.ClearLoginFailures(account); err != nil {
+		return nil, err
+	}
+	return u.startSession(user)
+}
+
+// mfaChallenge hands out the MFA token of a login waiting for its second
+// factor.
+func (u *UserUsecase) mfaChallenge(user *domain.User) (*domain.TokenPair, error) {
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := u.redis.SetOneTimeToken(mfaTokenPurpose, hashToken(token), user.ID, mfaChallengeTTL); err != nil {
+		return nil, err
+	}
+	return &domain.TokenPair{MFAToken: token}, nil
+}
+
+// checkSecondFactor accepts a code from the authenticator that wasn't used
+// before, or an unused recovery code, which is used up.
+func (u *UserUsecase) checkSecondFactor(enrollment *domain.TOTPEnrollment, code string) error {
+	if step, ok := totp.Validate(enrollment.Secret, code, time.Now(), totpSkew); ok {
+		fresh, err := u.mfaRepo.UseTOTPStep(enrollment.UserID, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return domain.ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	used, err := u.mfaRepo.UseRecoveryCode(enrollment.UserID, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return domain.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// newRecoveryCode returns a random code like "k3xq7-m2pwa".
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 7)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	s := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))[:10]
+	return s[:5] + "-" + s[5:], nil
+}
+
+// normalizeRecoveryCode lets users type recovery codes in any case and
+// without the dash.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+)
+
+// Roles returns all roles of the user: their main role and staff roles.
+func (u *UserUsecase) Roles(userID uint) ([]string, error) {
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Roles, err = u.userRepo.FindRoles(userID); err != nil {
+		return nil, err
+	}
+	return user.AllRoles(), nil
+}
+
+// SetRoles lets an admin replace the staff roles of a user. The user is
+// logged out everywhere, so the change applies at once.
+func (u *UserUsecase) SetRoles(admin domain.Viewer, userID uint, roles []string) error {
+	if !admin.Can(domain.PermRoleAssign) {
+		return domain.ErrPermissionDenied
+	}
+	if _, err := u.userRepo.FindByID(userID); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	unique := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if !domain.ValidStaffRole(role) {
+			return fmt.Errorf("invalid role %q", role)
+		}
+		if !seen[role] {
+			seen[role] = true
+			unique = append(unique, role)
+		}
+	}
+
+	if err := u.userRepo.SetRoles(userID, unique); err != nil {
+		return err
+	}
+	return u.redis.RevokeUserSessions(userID)
+}
+
+// SetBanned lets a moderator suspend or reinstate a user. A suspended user
+// is logged out everywhere and can't log in.
+func (u *UserUsecase) SetBanned(moderator domain.Viewer, userID uint, banned bool) error {
+	if !moderator.Can(domain.PermUserBan) {
+		return domain.ErrPermissionDenied
+	}
+	if moderator.UserID == userID {
+		return errors.New("cannot ban yourself")
+	}
+	// Moderators can't suspend the admins who appoint them.
+	roles, err := u.Roles(userID)
+	if err != nil {
+		return err
+	}
+	if domain.HasPermission(roles, domain.PermRoleAssign) && !moderator.Can(domain.PermRoleAssign) {
+		return domain.ErrPermissionDenied
+	}
+
+	if err := u.userRepo.SetBanned(userID, banned); err != nil {
+		return err
+	}
+	if !banned {
+		return nil
+	}
+	return u.redis.RevokeUserSessions(userID)
+}
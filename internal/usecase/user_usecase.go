@@ -3,7 +3,11 @@ package usecase
 import (
 	"errors"
 	"fmt"
+	"log"
+	"strings"
+	"time"
 
+	"github.com/Hamiduzzaman96/Blog-Service/config"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
 	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
 	"github.com/Hamiduzzaman96/Blog-Service/pkg/jwt"
@@ -11,30 +15,74 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// resetTokenPurpose namespaces password reset tokens in Redis.
+const resetTokenPurpose = "reset"
+
+// Password reset requests are limited per client address and per email
+// address within resetWindow.
+const (
+	resetWindow       = time.Hour
+	resetIPLimit      = 20
+	resetAddressLimit = 3
+)
+
 type UserUsecase struct {
-	userRepo *repository.UserRepository
-	jwtSvc   *jwt.Service
-	redis    *redis.Client
+	userRepo      *repository.UserRepository
+	mfaRepo       *repository.MFARepository
+	membership    *MembershipUsecase
+	notifications *NotificationUsecase
+	jwtSvc        *jwt.Service
+	redis         *redis.Client
+	cfg           config.AccountConfig
 }
 
 func NewUserUsecase(
 	userRepo *repository.UserRepository,
+	mfaRepo *repository.MFARepository,
+	membership *MembershipUsecase,
+	notifications *NotificationUsecase,
 	jwtSvc *jwt.Service,
 	redis *redis.Client,
+	cfg config.AccountConfig,
 ) *UserUsecase {
 	return &UserUsecase{
-		userRepo: userRepo,
-		jwtSvc:   jwtSvc,
-		redis:    redis,
+		userRepo:      userRepo,
+		mfaRepo:       mfaRepo,
+		membership:    membership,
+		notifications: notifications,
+		jwtSvc:        jwtSvc,
+		redis:         redis,
+		cfg:           cfg,
 	}
 }
 
+// Register creates a user with an unverified email address and emails
+// them a verification link.
 func (u *UserUsecase) Register(email, password string) (*domain.User, error) {
+	user, err := u.create(email, password, false)
+	if err != nil {
+		return nil, err
+	}
 
+	// The account exists either way; the user can ask for another link.
+	if err := u.sendVerification(user); err != nil {
+		log.Printf("verification email to user %d failed: %v", user.ID, err)
+	}
+	return user, nil
+}
+
+// ImportUser creates a user whose email address was verified elsewhere,
+// such as by the site an import comes from. No email is sent.
+func (u *UserUsecase) ImportUser(email, password string) (*domain.User, error) {
+	return u.create(email, password, true)
+}
+
+func (u *UserUsecase) create(email, password string, verified bool) (*domain.User, error) {
 	user, err := domain.NewUser(email, password)
 	if err != nil {
 		return nil, err
 	}
+	user.EmailVerified = verified
 
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -50,25 +98,228 @@ func (u *UserUsecase) Register(email, password string) (*domain.User, error) {
 	return createdUser, nil
 }
 
-func (u *UserUsecase) Login(email, password string) (string, error) {
+func (u *UserUsecase) GetByEmail(email string) (*domain.User, error) {
+	return u.userRepo.FindByEmail(email)
+}
+
+// Login checks the credentials and starts a session: an access token and a
+// refresh token of a new token family. ip is the client's address; failed
+// logins slow down and then lock out further attempts for the account and
+// the address. Users with two-factor authentication get an MFA token
+// instead, to finish the login with LoginMFA.
+func (u *UserUsecase) Login(email, password, ip string) (*domain.TokenPair, error) {
+	account, client := loginKeys(email, ip)
+	if err := u.checkLoginBlocked(account, client); err != nil {
+		return nil, err
+	}
+
 	userModel, err := u.userRepo.FindByEmail(email)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+	hash := noUserHash
+	if userModel != nil {
+		hash = userModel.Password
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil || userModel == nil {
+		u.recordLoginFailure(userModel, account, client)
+		return nil, domain.ErrInvalidCredentials
+	}
+	if userModel.Banned {
+		return nil, domain.ErrUserBanned
+	}
+
+	enrollment, err := u.mfaRepo.FindTOTP(userModel.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment != nil && enrollment.Enabled {
+		// Failures are cleared once the second factor is passed too.
+		return u.mfaChallenge(userModel)
+	}
+
+	if err := u.redis.ClearLoginFailures(account); err != nil {
+		return nil, err
+	}
+	return u.startSession(userModel)
+}
+
+// startSession issues the tokens of a new login session.
+func (u *UserUsecase) startSession(userModel *domain.User) (*domain.TokenPair, error) {
+	family, err := jwt.NewID()
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := u.accessToken(userModel, family)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, jti, err := u.jwtSvc.GenerateRefreshToken(userModel.ID, family)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	if err := u.redis.StartTokenFamily(family, jti, u.jwtSvc.RefreshTokenTTL()); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token in redis: %w", err)
+	}
+
+	return &domain.TokenPair{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// Refresh exchanges a refresh token for a new access token and a new
+// refresh token of the same family. Every refresh token works once: using
+// one again revokes its whole family, as it has probably been stolen.
+func (u *UserUsecase) Refresh(refreshToken string) (*domain.TokenPair, error) {
+	claims, err := u.jwtSvc.ValidateRefresh(refreshToken)
+	if err != nil {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	next, jti, err := u.jwtSvc.GenerateRefreshToken(claims.UserID, claims.Family)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	result, err := u.redis.RotateTokenFamily(claims.Family, claims.ID, jti, u.jwtSvc.RefreshTokenTTL())
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	switch result {
+	case redis.RotateReused:
+		// The access tokens of the session go with it.
+		if err := u.redis.RevokeSession(claims.UserID, claims.Family); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrRefreshTokenReused
+	case redis.RotateUnknown:
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	// Roles and tier are read again, so promotions show up on refresh.
+	user, err := u.userRepo.FindByID(claims.UserID)
+	if err != nil || user.Banned {
+		u.redis.RevokeTokenFamily(claims.Family)
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	accessToken, err := u.accessToken(user, claims.Family)
 	if err != nil {
-		return "", errors.New("user not found")
+		return nil, err
+	}
+
+	return &domain.TokenPair{AccessToken: accessToken, RefreshToken: next}, nil
+}
+
+// Logout ends one login session of the user: its access and refresh tokens
+// stop working.
+func (u *UserUsecase) Logout(userID uint, session string) error {
+	return u.redis.RevokeSession(userID, session)
+}
+
+// LogoutEverywhere ends every login session of the user.
+func (u *UserUsecase) LogoutEverywhere(userID uint) error {
+	return u.redis.RevokeUserSessions(userID)
+}
+
+// ForgotPassword emails a password reset link to the user with the email,
+// invalidating earlier links. Requests from ip beyond the hourly limit fail
+// with domain.ErrResetThrottled. The rest happens in the background and
+// errors are only logged, so the answer takes as long whether or not the
+// email has an account; unknown and too often asked for addresses are
+// skipped silently.
+func (u *UserUsecase) ForgotPassword(email, ip string) error {
+	ok, err := u.redis.Allow("reset:ip:"+ip, resetIPLimit, resetWindow)
+	if err != nil {
+		return err
 	}
+	if !ok {
+		return domain.ErrResetThrottled
+	}
+
+	go func() {
+		if err := u.sendResetLink(email); err != nil {
+			log.Printf("password reset for %s failed: %v", email, err)
+		}
+	}()
+	return nil
+}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(userModel.Password), []byte(password)); err != nil {
-		return "", errors.New("invalid credentials")
+func (u *UserUsecase) sendResetLink(email string) error {
+	ok, err := u.redis.Allow("reset:email:"+strings.ToLower(strings.TrimSpace(email)), resetAddressLimit, resetWindow)
+	if err != nil || !ok {
+		return err
+	}
+	user, err := u.userRepo.FindByEmail(email)
+	if err != nil {
+		return nil
 	}
 
-	token, err := u.jwtSvc.GenerateAccessToken(userModel.ID, userModel.Role)
+	token, err := randomToken()
+	if err != nil {
+		return err
+	}
+	hash := hashToken(token)
+	ttl := time.Duration(u.cfg.ResetTTLMin) * time.Minute
+	if err := u.redis.ReplaceOneTimeToken(resetTokenPurpose, hash, user.ID, ttl); err != nil {
+		return fmt.Errorf("failed to store reset token: %w", err)
+	}
+
+	link := u.cfg.PublicURL + "/password/reset?token=" + token
+	body := fmt.Sprintf("Someone asked to reset the password of your account.\n\n"+
+		"To choose a new password, open this link within %d minutes:\n%s\n\n"+
+		"If it wasn't you, ignore this email; your password stays the same.\n",
+		u.cfg.ResetTTLMin, link)
+	return u.notifications.Email("password-reset:"+hash, user.Email, "Reset your password", body)
+}
+
+// ResetPassword sets a new password with a token from ForgotPassword. The
+// token works once, and every session of the user is logged out.
+func (u *UserUsecase) ResetPassword(token, password string) error {
+	if err := domain.ValidatePassword(password); err != nil {
+		return err
+	}
+
+	userID, ok, err := u.redis.TakeOneTimeToken(resetTokenPurpose, hashToken(token))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrInvalidResetToken
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	if err := u.userRepo.UpdatePassword(userID, string(hash)); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if err := u.redis.RevokeUserSessions(userID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return u.notifications.Send(userID, "Your password was reset and all sessions were logged out.")
+}
+
+// accessToken issues an access token of the session carrying the user's
+// roles and tier, and lists it as valid until the session is revoked.
+func (u *UserUsecase) accessToken(user *domain.User, session string) (string, error) {
+	tier, err := u.membership.Tier(user.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load membership: %w", err)
+	}
+	if user.Roles, err = u.userRepo.FindRoles(user.ID); err != nil {
+		return "", fmt.Errorf("failed to load roles: %w", err)
+	}
+
+	token, jti, err := u.jwtSvc.GenerateAccessToken(user.ID, user.Role, user.AllRoles(), tier, session)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate token: %w", err)
 	}
 
-	if err := u.redis.SetToken(token, userModel.ID); err != nil {
+	if err := u.redis.SetToken(jti, user.ID); err != nil {
+		return "", fmt.Errorf("failed to store token in redis: %w", err)
+	}
+	if err := u.redis.AddSessionToken(user.ID, session, jti, u.jwtSvc.RefreshTokenTTL()); err != nil {
 		return "", fmt.Errorf("failed to store token in redis: %w", err)
 	}
-
 	return token, nil
 }
 
@@ -79,7 +330,10 @@ func (u *UserUsecase) PromoteToAuthor(userID uint) error {
 	}
 
 	if user.Role == domain.RoleAuthor {
-		return errors.New("already an author")
+		return domain.ErrAlreadyAuthor
+	}
+	if !user.EmailVerified {
+		return domain.ErrEmailNotVerified
 	}
 
 	user.PromoteToAuthor()
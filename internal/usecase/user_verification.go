@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+)
+
+// ResendVerification emails the user a new verification link, at most once
+// per ResendIntervalSec.
+func (u *UserUsecase) ResendVerification(userID uint) error {
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified {
+		return domain.ErrEmailAlreadyVerified
+	}
+
+	interval := time.Duration(u.cfg.ResendIntervalSec) * time.Second
+	ok, err := u.redis.Throttle(fmt.Sprintf("verify-email:%d", userID), interval)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrVerificationThrottled
+	}
+	return u.sendVerification(user)
+}
+
+// VerifyEmail marks the user's email verified with the parameters of a
+// signed verification link. The signature covers the email address, so a
+// link stops working if the address changes.
+func (u *UserUsecase) VerifyEmail(userID uint, expires int64, signature string) error {
+	if time.Now().Unix() > expires {
+		return domain.ErrInvalidVerification
+	}
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return domain.ErrInvalidVerification
+	}
+	if !hmac.Equal([]byte(signature), []byte(u.signVerification(user, expires))) {
+		return domain.ErrInvalidVerification
+	}
+	if user.EmailVerified {
+		return nil
+	}
+	return u.userRepo.MarkEmailVerified(user.ID)
+}
+
+func (u *UserUsecase) sendVerification(user *domain.User) error {
+	expires := time.Now().Add(time.Duration(u.cfg.VerifyTTLHours) * time.Hour).Unix()
+	query := url.Values{
+		"user_id": {strconv.FormatUint(uint64(user.ID), 10)},
+		"expires": {strconv.FormatInt(expires, 10)},
+		"sig":     {u.signVerification(user, expires)},
+	}
+	link := u.cfg.PublicURL + "/verify-email?" + query.Encode()
+
+	body := fmt.Sprintf("Please confirm that this is your email address by opening this link:\n\n%s\n\n"+
+		"The link works for %d hours. If you did not create an account, ignore this email.\n",
+		link, u.cfg.VerifyTTLHours)
+	return u.notifications.Email(
+		fmt.Sprintf("verify-email:%d:%d", user.ID, expires), user.Email, "Verify your email address", body)
+}
+
+func (u *UserUsecase) signVerification(user *domain.User, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(u.cfg.Secret))
+	fmt.Fprintf(mac, "verify-email:%d:%s:%d", user.ID, user.Email, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/Hamiduzzaman96/Blog-Service/internal/domain"
+	"github.com/Hamiduzzaman96/Blog-Service/internal/repository"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/redis"
+	"github.com/Hamiduzzaman96/Blog-Service/pkg/webmention"
+)
+
+const (
+	// webmentionBatch is the number of webmentions sent or verified per run.
+	webmentionBatch = 50
+
+	// Received webmentions are limited per client address and per source
+	// host, as each one makes the service fetch the source.
+	webmentionWindow      = time.Hour
+	webmentionIPLimit     = 60
+	webmentionSourceLimit = 20
+)
+
+// WebmentionUsecase notifies the sites a new post links to and turns
+// webmentions received for posts into comments awaiting moderation.
+type WebmentionUsecase struct {
+	repo        *repository.WebmentionRepository
+	commentRepo *repository.CommentRepository
+	blogs       *BlogUsecase
+	client      *webmention.Client
+	throttle    *redis.Client
+}
+
+func NewWebmentionUsecase(
+	repo *repository.WebmentionRepository,
+	commentRepo *repository.CommentRepository,
+	blogs *BlogUsecase,
+	client *webmention.Client,
+	throttle *redis.Client,
+) *WebmentionUsecase {
+	return &WebmentionUsecase{
+		repo:        repo,
+		commentRepo: commentRepo,
+		blogs:       blogs,
+		client:      client,
+		throttle:    throttle,
+	}
+}
+
+// SENDING
+
+// HandlePostEvent is the RabbitMQ handler for blog.published: it queues a
+// webmention for every link of the post. Only posts anyone may read are
+// announced, and only links before a paywall, as the targets verify the
+// mention against what they can read.
+func (u *WebmentionUsecase) HandlePostEvent(body []byte) error {
+	var e domain.PostEvent
+	if err := json.Unmarshal(body, &e); err != nil {
+		return err
+	}
+	if e.Type != domain.EventPostPublished || e.Post == nil || !e.Post.VisibleTo(domain.Viewer{}, false) {
+		return nil
+	}
+
+	blogs, err := u.blogs.forPublicationID(e.Post.PublicationID)
+	if err != nil {
+		return err
+	}
+	content := e.Post.Content
+	if e.Post.Paywalled(domain.Viewer{}) {
+		content = domain.Preview(content)
+	}
+
+	source := blogs.PostURL(e.Post.Slug)
+	var ws []*domain.OutgoingWebmention
+	for _, target := range webmention.Links(content) {
+		if target == source {
+			continue
+		}
+		ws = append(ws, &domain.OutgoingWebmention{PostID: e.Post.ID, Source: source, Target: target})
+	}
+	return u.repo.EnqueueOutgoing(ws...)
+}
+
+// SendPending sends the webmentions that are due and returns how many were
+// sent. Targets without a Webmention endpoint are skipped for good.
+func (u *WebmentionUsecase) SendPending() (int, error) {
+	now := time.Now()
+	ws, err := u.repo.FindDueOutgoing(now, webmentionBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, w := range ws {
+		endpoint, err := u.client.Discover(w.Target)
+		if err == nil {
+			err = u.client.Send(endpoint, w.Source, w.Target)
+		}
+		switch {
+		case errors.Is(err, webmention.ErrNoEndpoint):
+			w.LastError = err.Error()
+			err = u.repo.SaveOutgoing(w, false)
+		case err != nil:
+			retry := w.Failed(err, now)
+			if !retry {
+				log.Printf("webmention: giving up sending %s -> %s: %v", w.Source, w.Target, err)
+			}
+			err = u.repo.SaveOutgoing(w, retry)
+		default:
+			w.LastError = ""
+			sent++
+			err = u.repo.SaveOutgoing(w, false)
+		}
+		if err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// RECEIVING
+
+// Receive accepts a webmention sent from ip for a post of pub (nil for the
+// default publication). The source is verified later by VerifyPending.
+func (u *WebmentionUsecase) Receive(pub *domain.Publication, source, target, ip string) error {
+	if !webURL(source) || !webURL(target) || source == target {
+		return domain.ErrInvalidWebmention
+	}
+	src, _ := url.Parse(source)
+	for key, limit := range map[string]int64{
+		"webmention:ip:" + ip:                 webmentionIPLimit,
+		"webmention:source:" + src.Hostname(): webmentionSourceLimit,
+	} {
+		ok, err := u.throttle.Allow(key, limit, webmentionWindow)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return domain.ErrWebmentionThrottled
+		}
+	}
+
+	post, err := u.blogs.ForPublication(pub).MentionedPost(target)
+	if err != nil {
+		return err
+	}
+	return u.repo.SaveReceived(&domain.IncomingWebmention{Source: source, Target: target, BlogID: post.ID})
+}
+
+// VerifyPending verifies the received webmentions that are due and returns
+// how many were verified. A verified mention is stored as a pending comment
+// on the post, updated when the source mentions it again and removed once
+// the source no longer links to it.
+func (u *WebmentionUsecase) VerifyPending() (int, error) {
+	now := time.Now()
+	ws, err := u.repo.FindDueIncoming(now, webmentionBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	verified := 0
+	for _, w := range ws {
+		mention, err := u.client.Verify(w.Source, w.Target)
+		retry := false
+		switch {
+		case errors.Is(err, webmention.ErrNoLink):
+			w.Status, w.LastError = domain.WebmentionInvalid, err.Error()
+			if w.CommentID != 0 {
+				if err := u.commentRepo.Delete(w.CommentID); err != nil {
+					return verified, err
+				}
+				w.CommentID = 0
+			}
+		case err != nil:
+			if retry = w.Failed(err, now); !retry {
+				log.Printf("webmention: giving up verifying %s -> %s: %v", w.Source, w.Target, err)
+				w.Status = domain.WebmentionInvalid
+			}
+		default:
+			if err := u.saveComment(w, mention); err != nil {
+				return verified, err
+			}
+			w.Status, w.LastError = domain.WebmentionVerified, ""
+			verified++
+		}
+		if err := u.repo.SaveIncoming(w, retry); err != nil {
+			return verified, err
+		}
+	}
+	return verified, nil
+}
+
+// saveComment creates or updates the comment showing a verified mention.
+func (u *WebmentionUsecase) saveComment(w *domain.IncomingWebmention, mention *webmention.Mention) error {
+	name := mention.Title
+	if name == "" {
+		if src, err := url.Parse(w.Source); err == nil {
+			name = src.Host
+		}
+	}
+
+	c := domain.NewComment(w.BlogID, 0, fmt.Sprintf("Mentioned this post at %s", w.Source))
+	c.AuthorName = name
+	c.AuthorURL = w.Source
+	if w.CommentID != 0 {
+		c.ID = w.CommentID
+		return u.commentRepo.Update(c)
+	}
+
+	created, err := u.commentRepo.Create(c)
+	if err != nil {
+		return err
+	}
+	w.CommentID = created.ID
+	return nil
+}
+
+// webURL reports whether raw is an absolute http(s) URL.
+func webURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
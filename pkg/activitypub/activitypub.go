@@ -0,0 +1,146 @@
+// Package activitypub implements the parts of ActivityPub
+// (https://www.w3.org/TR/activitypub/) and WebFinger (RFC 7033) needed to
+// make authors followable from the fediverse: actors, collections and
+// activities, HTTP Signatures and signed delivery.
+package activitypub
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ContentType is the media type of ActivityPub documents.
+const ContentType = `application/activity+json`
+
+// AcceptHeader is sent when fetching remote ActivityPub documents.
+const AcceptHeader = `application/activity+json, application/ld+json; profile="https://www.w3.org/ns/activitystreams"`
+
+// Public is the special collection addressing an activity to everyone.
+const Public = "https://www.w3.org/ns/activitystreams#Public"
+
+// Context is the JSON-LD context of the documents served, including the
+// security vocabulary for the actor's publicKey.
+var Context = []string{
+	"https://www.w3.org/ns/activitystreams",
+	"https://w3id.org/security/v1",
+}
+
+type Actor struct {
+	Context           any        `json:"@context,omitempty"`
+	ID                string     `json:"id"`
+	Type              string     `json:"type"`
+	PreferredUsername string     `json:"preferredUsername,omitempty"`
+	Name              string     `json:"name,omitempty"`
+	Summary           string     `json:"summary,omitempty"`
+	URL               string     `json:"url,omitempty"`
+	Inbox             string     `json:"inbox"`
+	Outbox            string     `json:"outbox,omitempty"`
+	Followers         string     `json:"followers,omitempty"`
+	Endpoints         *Endpoints `json:"endpoints,omitempty"`
+	PublicKey         *PublicKey `json:"publicKey,omitempty"`
+}
+
+type Endpoints struct {
+	SharedInbox string `json:"sharedInbox,omitempty"`
+}
+
+type PublicKey struct {
+	ID           string `json:"id"`
+	Owner        string `json:"owner"`
+	PublicKeyPem string `json:"publicKeyPem"`
+}
+
+// Activity is an activity such as Create, Follow, Accept or Undo. Object is
+// either an object ID or an embedded object, depending on the activity.
+type Activity struct {
+	Context   any             `json:"@context,omitempty"`
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Actor     string          `json:"actor"`
+	Object    json.RawMessage `json:"object,omitempty"`
+	To        []string        `json:"to,omitempty"`
+	CC        []string        `json:"cc,omitempty"`
+	Published *time.Time      `json:"published,omitempty"`
+}
+
+// ObjectID returns the ID of the activity's object, whether it is given as
+// a string or embedded.
+func (a *Activity) ObjectID() string {
+	var id string
+	if json.Unmarshal(a.Object, &id) == nil {
+		return id
+	}
+	var obj struct {
+		ID string `json:"id"`
+	}
+	json.Unmarshal(a.Object, &obj)
+	return obj.ID
+}
+
+// EmbeddedActivity decodes an object that is itself an activity, as in
+// Undo{Follow} or Accept{Follow}.
+func (a *Activity) EmbeddedActivity() (*Activity, bool) {
+	var inner Activity
+	if err := json.Unmarshal(a.Object, &inner); err != nil || inner.Type == "" {
+		return nil, false
+	}
+	return &inner, true
+}
+
+// SetObject embeds obj, or sets an object ID when obj is a string.
+func (a *Activity) SetObject(obj any) error {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	a.Object = raw
+	return nil
+}
+
+// Article is a blog post.
+type Article struct {
+	Context      any        `json:"@context,omitempty"`
+	ID           string     `json:"id"`
+	Type         string     `json:"type"`
+	AttributedTo string     `json:"attributedTo"`
+	Name         string     `json:"name"`
+	Summary      string     `json:"summary,omitempty"`
+	Content      string     `json:"content"`
+	URL          string     `json:"url"`
+	Tag          []Hashtag  `json:"tag,omitempty"`
+	To           []string   `json:"to,omitempty"`
+	CC           []string   `json:"cc,omitempty"`
+	Published    *time.Time `json:"published,omitempty"`
+	Updated      *time.Time `json:"updated,omitempty"`
+}
+
+type Hashtag struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// OrderedCollection is a collection such as an outbox. Paged collections
+// link to their first page and leave OrderedItems empty.
+type OrderedCollection struct {
+	Context      any    `json:"@context,omitempty"`
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	TotalItems   int    `json:"totalItems"`
+	First        string `json:"first,omitempty"`
+	PartOf       string `json:"partOf,omitempty"`
+	Next         string `json:"next,omitempty"`
+	OrderedItems []any  `json:"orderedItems,omitempty"`
+}
+
+// JRD is a WebFinger response.
+type JRD struct {
+	Subject string    `json:"subject"`
+	Aliases []string  `json:"aliases,omitempty"`
+	Links   []JRDLink `json:"links"`
+}
+
+type JRDLink struct {
+	Rel  string `json:"rel"`
+	Type string `json:"type,omitempty"`
+	Href string `json:"href"`
+}
@@ -0,0 +1,76 @@
+package activitypub
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxDocumentSize caps remote documents read by the client.
+const maxDocumentSize = 1 << 20
+
+// Client fetches remote actors and delivers activities to their inboxes.
+// Actor and inbox URLs come from remote servers, so h should be a
+// safehttp client.
+type Client struct {
+	http *http.Client
+}
+
+func NewClient(h *http.Client) *Client {
+	return &Client{http: h}
+}
+
+// FetchActor retrieves the actor document at url.
+func (c *Client) FetchActor(url string) (*Actor, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", AcceptHeader)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching actor %s: %s", url, resp.Status)
+	}
+
+	var actor Actor
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxDocumentSize)).Decode(&actor); err != nil {
+		return nil, err
+	}
+	if actor.ID == "" || actor.Inbox == "" {
+		return nil, fmt.Errorf("fetching actor %s: not an actor", url)
+	}
+	return &actor, nil
+}
+
+// Deliver POSTs the JSON activity to inbox, signed as keyID.
+func (c *Client) Deliver(inbox string, activity []byte, keyID string, key *rsa.PrivateKey) error {
+	req, err := http.NewRequest(http.MethodPost, inbox, bytes.NewReader(activity))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", ContentType)
+	req.Header.Set("Accept", AcceptHeader)
+	if err := Sign(req, activity, keyID, key); err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxDocumentSize))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("delivering to %s: %s", inbox, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package frontmatter
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const delimiter = "---"
+
+// Meta is the subset of front matter understood by the importer.
+// It matches what Hugo and Jekyll emit for a post.
+type Meta struct {
+	Title      string     `yaml:"title"`
+	Date       string     `yaml:"date,omitempty"`
+	Tags       stringList `yaml:"tags,omitempty"`
+	Categories stringList `yaml:"categories,omitempty"`
+	Slug       string     `yaml:"slug,omitempty"`
+	Draft      bool       `yaml:"draft"`
+}
+
+// stringList accepts both `tags: [a, b]` and `tags: a, b`.
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		for _, t := range strings.Split(n.Value, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				*l = append(*l, t)
+			}
+		}
+		return nil
+	}
+
+	var items []string
+	if err := n.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseDate understands the date formats used by common static-site generators.
+func ParseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("unrecognised date: " + s)
+}
+
+// Parse splits a markdown document into its front matter and body.
+// Documents without front matter return an empty Meta and the full input.
+func Parse(data []byte) (Meta, string, error) {
+	var meta Meta
+
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.TrimPrefix(text, "\ufeff")
+	if !strings.HasPrefix(text, delimiter+"\n") {
+		return meta, text, nil
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], "\n") != delimiter {
+			continue
+		}
+
+		head := strings.Join(lines[1:i], "")
+		if err := yaml.Unmarshal([]byte(head), &meta); err != nil {
+			return meta, "", err
+		}
+
+		body := strings.Join(lines[i+1:], "")
+		return meta, strings.TrimPrefix(body, "\n"), nil
+	}
+
+	return meta, "", errors.New("front matter is not closed")
+}
+
+// Render writes meta as YAML front matter followed by body.
+func Render(meta Meta, body string) ([]byte, error) {
+	head, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(delimiter + "\n")
+	buf.Write(head)
+	buf.WriteString(delimiter + "\n\n")
+	buf.WriteString(body)
+	if !strings.HasSuffix(body, "\n") {
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
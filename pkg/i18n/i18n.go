@@ -0,0 +1,67 @@
+// Package i18n normalises locale tags and picks the best available locale
+// for a reader.
+package i18n
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Normalize returns the canonical BCP 47 form of a locale, e.g. "pt-br" → "pt-BR".
+func Normalize(locale string) (string, error) {
+	tag, err := language.Parse(strings.TrimSpace(locale))
+	if err != nil {
+		return "", err
+	}
+	return tag.String(), nil
+}
+
+// Preferred parses an Accept-Language style list ("fr-CH, fr;q=0.9, en;q=0.8")
+// into locales ordered by preference. A single locale is a valid list.
+// Unparseable input yields no preferences.
+func Preferred(header string) []string {
+	if strings.TrimSpace(header) == "" {
+		return nil
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if t != language.Und {
+			out = append(out, t.String())
+		}
+	}
+	return out
+}
+
+// Match returns the first of available that satisfies the preferred locales,
+// then the fallback chain, trying an exact match before a base language
+// match for each candidate ("pt-BR" is served by "pt" and vice versa).
+// It returns "" when nothing matches.
+func Match(available, preferred, fallback []string) string {
+	candidates := append(append([]string{}, preferred...), fallback...)
+
+	for _, want := range candidates {
+		for _, have := range available {
+			if strings.EqualFold(want, have) {
+				return have
+			}
+		}
+		for _, have := range available {
+			if base(want) == base(have) {
+				return have
+			}
+		}
+	}
+	return ""
+}
+
+func base(locale string) string {
+	b, _ := language.Make(locale).Base()
+	return b.String()
+}
@@ -1,12 +1,20 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Token types, carried in the "typ" claim.
+const (
+	TypeAccess  = "access"
+	TypeRefresh = "refresh"
+)
+
 type Service struct {
 	secret          string
 	accessTokenTTL  time.Duration
@@ -21,30 +29,96 @@ func NewService(secret string, accessExpMin int, refreshExpDay int) *Service {
 	}
 }
 
-func (s *Service) generateToken(userID uint, role string, ttl time.Duration) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id": userID,
-		"role":    role,
-		"exp":     time.Now().Add(ttl).Unix(),
-		"iat":     time.Now().Unix(),
-	}
+func (s *Service) sign(claims jwt.MapClaims, ttl time.Duration) (string, error) {
+	claims["exp"] = time.Now().Add(ttl).Unix()
+	claims["iat"] = time.Now().Unix()
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(s.secret))
 }
 
-// Access Token
-func (s *Service) GenerateAccessToken(userID uint, role string) (string, error) {
-	return s.generateToken(userID, role, s.accessTokenTTL)
+// GenerateAccessToken issues an access token of the login session and
+// returns it with its unique ID (jti). role is the user's main role, roles
+// all the roles permissions are resolved from.
+func (s *Service) GenerateAccessToken(userID uint, role string, roles []string, tier, session string) (string, string, error) {
+	jti, err := NewID()
+	if err != nil {
+		return "", "", err
+	}
+	token, err := s.sign(jwt.MapClaims{
+		"typ":     TypeAccess,
+		"jti":     jti,
+		"sid":     session,
+		"user_id": userID,
+		"role":    role,
+		"roles":   roles,
+		"tier":    tier,
+	}, s.accessTokenTTL)
+	return token, jti, err
+}
+
+// RefreshTokenTTL is the lifetime of a refresh token.
+func (s *Service) RefreshTokenTTL() time.Duration {
+	return s.refreshTokenTTL
+}
+
+// GenerateRefreshToken issues a refresh token of the token family and
+// returns it with its unique ID (jti).
+func (s *Service) GenerateRefreshToken(userID uint, family string) (string, string, error) {
+	jti, err := NewID()
+	if err != nil {
+		return "", "", err
+	}
+	token, err := s.sign(jwt.MapClaims{
+		"typ":     TypeRefresh,
+		"jti":     jti,
+		"fam":     family,
+		"user_id": userID,
+	}, s.refreshTokenTTL)
+	return token, jti, err
 }
 
-// Refresh Token
-func (s *Service) GenerateRefreshToken(userID uint, role string) (string, error) {
-	return s.generateToken(userID, role, s.refreshTokenTTL)
+// RefreshClaims are the claims of a valid refresh token.
+type RefreshClaims struct {
+	UserID uint
+	Family string
+	ID     string
 }
 
-// Validate Token
+// ValidateRefresh checks a refresh token and returns its claims.
+func (s *Service) ValidateRefresh(tokenStr string) (*RefreshClaims, error) {
+	claims, err := s.parse(tokenStr)
+	if err != nil {
+		return nil, err
+	}
+	if typ, _ := claims["typ"].(string); typ != TypeRefresh {
+		return nil, errors.New("not a refresh token")
+	}
+
+	userID, _ := claims["user_id"].(float64)
+	family, _ := claims["fam"].(string)
+	jti, _ := claims["jti"].(string)
+	if userID == 0 || family == "" || jti == "" {
+		return nil, errors.New("invalid claims")
+	}
+	return &RefreshClaims{UserID: uint(userID), Family: family, ID: jti}, nil
+}
+
+// Validate checks an access token and returns its claims. Refresh tokens
+// are rejected.
 func (s *Service) Validate(tokenStr string) (*jwt.MapClaims, error) {
+	claims, err := s.parse(tokenStr)
+	if err != nil {
+		return nil, err
+	}
+	// Tokens issued before token types were introduced carry no typ.
+	if typ, ok := claims["typ"].(string); ok && typ != TypeAccess {
+		return nil, errors.New("invalid token")
+	}
+	return &claims, nil
+}
+
+func (s *Service) parse(tokenStr string) (jwt.MapClaims, error) {
 	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (any, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
@@ -61,5 +135,14 @@ func (s *Service) Validate(tokenStr string) (*jwt.MapClaims, error) {
 		return nil, errors.New("invalid claims")
 	}
 
-	return &claims, nil
+	return claims, nil
+}
+
+// NewID returns a random token or token family ID.
+func NewID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
 }
@@ -0,0 +1,270 @@
+// Package micropub parses Micropub requests (https://www.w3.org/TR/micropub/)
+// in their form-encoded, multipart and JSON syntaxes into one shape, and
+// defines the protocol's error and query responses.
+package micropub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxRequestSize caps the body of a Micropub request.
+const maxRequestSize = 1 << 20
+
+// Actions of a Micropub request.
+const (
+	ActionCreate   = "create"
+	ActionUpdate   = "update"
+	ActionDelete   = "delete"
+	ActionUndelete = "undelete"
+)
+
+// Error is a Micropub error response.
+type Error struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// Status is the HTTP status code of the error.
+func (e *Error) Status() int {
+	switch e.Code {
+	case "unauthorized":
+		return http.StatusUnauthorized
+	case "forbidden", "insufficient_scope":
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// InvalidRequest returns an invalid_request error.
+func InvalidRequest(format string, args ...any) *Error {
+	return &Error{Code: "invalid_request", Description: fmt.Sprintf(format, args...)}
+}
+
+// Properties are microformats2 properties, each a list of values. Values
+// are strings or, in JSON requests, objects such as {"html": "..."}.
+type Properties map[string][]any
+
+// String returns the first value of name as plain text, or "" if it has
+// none. An {"html": ...} object returns its HTML and {"value": ...} its
+// value.
+func (p Properties) String(name string) string {
+	if len(p[name]) == 0 {
+		return ""
+	}
+	return valueString(p[name][0])
+}
+
+// Strings returns the values of name as text.
+func (p Properties) Strings(name string) []string {
+	out := make([]string, 0, len(p[name]))
+	for _, v := range p[name] {
+		if s := valueString(v); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func valueString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case map[string]any:
+		for _, key := range []string{"html", "value"} {
+			if s, ok := v[key].(string); ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// Request is a parsed Micropub request.
+type Request struct {
+	Action     string
+	Type       string // h-* type of a created object, e.g. "h-entry"
+	Properties Properties
+	// URL is the object an update, delete or undelete acts on.
+	URL string
+	// Replace, Add and Delete hold the changes of an update. Delete maps a
+	// property to the values to remove, or to nil to remove it entirely.
+	Replace Properties
+	Add     Properties
+	Delete  Properties
+}
+
+// Parse reads a Micropub request from the body of r.
+func Parse(r *http.Request) (*Request, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	r.Body = http.MaxBytesReader(nil, r.Body, maxRequestSize)
+
+	if mediaType == "application/json" {
+		return parseJSON(r.Body)
+	}
+
+	var err error
+	if mediaType == "multipart/form-data" {
+		err = r.ParseMultipartForm(maxRequestSize)
+	} else {
+		err = r.ParseForm()
+	}
+	if err != nil {
+		return nil, InvalidRequest("malformed form: %v", err)
+	}
+	return parseForm(r.PostForm)
+}
+
+// parseForm reads the form syntax: h=entry, properties as fields and
+// name[] for multiple values. Fields starting with mp- are commands and
+// kept as properties.
+func parseForm(form url.Values) (*Request, error) {
+	req := &Request{Action: form.Get("action"), URL: form.Get("url"), Properties: Properties{}}
+	if req.Action == "" {
+		req.Action = ActionCreate
+	}
+	if req.Action != ActionCreate {
+		if req.Action == ActionUpdate {
+			return nil, InvalidRequest("updates must be sent as JSON")
+		}
+		return req, validate(req)
+	}
+
+	req.Type = "h-" + form.Get("h")
+	if form.Get("h") == "" {
+		req.Type = "h-entry"
+	}
+	for key, values := range form {
+		name := strings.TrimSuffix(key, "[]")
+		if name == "h" || name == "access_token" || name == "action" {
+			continue
+		}
+		for _, v := range values {
+			req.Properties[name] = append(req.Properties[name], v)
+		}
+	}
+	return req, validate(req)
+}
+
+// parseJSON reads the JSON syntax.
+func parseJSON(body io.Reader) (*Request, error) {
+	var raw struct {
+		Type       []string        `json:"type"`
+		Properties Properties      `json:"properties"`
+		Action     string          `json:"action"`
+		URL        string          `json:"url"`
+		Replace    Properties      `json:"replace"`
+		Add        Properties      `json:"add"`
+		Delete     json.RawMessage `json:"delete"`
+	}
+	if err := json.NewDecoder(body).Decode(&raw); err != nil {
+		return nil, InvalidRequest("malformed JSON: %v", err)
+	}
+
+	req := &Request{
+		Action:     raw.Action,
+		Properties: raw.Properties,
+		URL:        raw.URL,
+		Replace:    raw.Replace,
+		Add:        raw.Add,
+	}
+	if req.Action == "" {
+		req.Action = ActionCreate
+	}
+	if len(raw.Type) > 0 {
+		req.Type = raw.Type[0]
+	}
+	if req.Properties == nil {
+		req.Properties = Properties{}
+	}
+
+	if len(raw.Delete) > 0 {
+		// Either a list of properties to remove or the values to remove.
+		var names []string
+		if err := json.Unmarshal(raw.Delete, &names); err == nil {
+			req.Delete = Properties{}
+			for _, n := range names {
+				req.Delete[n] = nil
+			}
+		} else if err := json.Unmarshal(raw.Delete, &req.Delete); err != nil {
+			return nil, InvalidRequest("delete must be a list of properties or an object of values")
+		}
+	}
+	return req, validate(req)
+}
+
+func validate(req *Request) error {
+	switch req.Action {
+	case ActionCreate:
+		if req.Type == "" {
+			return InvalidRequest("type is required")
+		}
+	case ActionUpdate, ActionDelete, ActionUndelete:
+		if req.URL == "" {
+			return InvalidRequest("url is required to %s", req.Action)
+		}
+	default:
+		return InvalidRequest("unknown action %q", req.Action)
+	}
+	return nil
+}
+
+// Entry is the source of an object returned by q=source.
+type Entry struct {
+	Type       []string   `json:"type,omitempty"`
+	Properties Properties `json:"properties"`
+}
+
+// Config is the q=config response.
+type Config struct {
+	MediaEndpoint string     `json:"media-endpoint,omitempty"`
+	SyndicateTo   []any      `json:"syndicate-to"`
+	Q             []string   `json:"q"`
+	PostTypes     []PostType `json:"post-types,omitempty"`
+}
+
+// PostType announces a kind of post the server supports.
+type PostType struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// ErrNoFile is returned by MediaFile when an upload has no file part.
+var ErrNoFile = errors.New("file is required")
+
+// MediaFile returns the "file" part of a media endpoint upload, limited to
+// maxSize bytes.
+func MediaFile(r *http.Request, maxSize int64) (name string, data []byte, err error) {
+	r.Body = http.MaxBytesReader(nil, r.Body, maxSize+1<<10)
+	file, header, err := r.FormFile("file")
+	if errors.Is(err, http.ErrMissingFile) {
+		return "", nil, ErrNoFile
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	defer file.Close()
+
+	data, err = io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return "", nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return "", nil, fmt.Errorf("file is larger than %d bytes", maxSize)
+	}
+	return header.Filename, data, nil
+}
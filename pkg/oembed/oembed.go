@@ -0,0 +1,62 @@
+// Package oembed implements the provider side of oEmbed 1.0
+// (https://oembed.com) for "rich" embeds.
+package oembed
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// ErrTooSmall is returned when the consumer's size limits are below the
+// smallest embed the provider can render; the spec answers 501.
+var ErrTooSmall = errors.New("no embed fits within maxwidth/maxheight")
+
+// Response is a "rich" oEmbed response.
+type Response struct {
+	XMLName      xml.Name `json:"-" xml:"oembed"`
+	Type         string   `json:"type" xml:"type"`
+	Version      string   `json:"version" xml:"version"`
+	Title        string   `json:"title,omitempty" xml:"title,omitempty"`
+	AuthorName   string   `json:"author_name,omitempty" xml:"author_name,omitempty"`
+	AuthorURL    string   `json:"author_url,omitempty" xml:"author_url,omitempty"`
+	ProviderName string   `json:"provider_name,omitempty" xml:"provider_name,omitempty"`
+	ProviderURL  string   `json:"provider_url,omitempty" xml:"provider_url,omitempty"`
+	CacheAge     int      `json:"cache_age,omitempty" xml:"cache_age,omitempty"`
+	HTML         string   `json:"html" xml:"html"`
+	Width        int      `json:"width" xml:"width"`
+	Height       int      `json:"height" xml:"height"`
+}
+
+// Size is the preferred and minimum size of an embed.
+type Size struct {
+	Width, Height       int
+	MinWidth, MinHeight int
+}
+
+// Fit shrinks the preferred size to the consumer's maxwidth and maxheight,
+// where 0 means unlimited.
+func (s Size) Fit(maxWidth, maxHeight int) (int, int, error) {
+	w, h := s.Width, s.Height
+	if maxWidth > 0 && maxWidth < w {
+		w = maxWidth
+	}
+	if maxHeight > 0 && maxHeight < h {
+		h = maxHeight
+	}
+	if w < s.MinWidth || h < s.MinHeight {
+		return 0, 0, ErrTooSmall
+	}
+	return w, h, nil
+}
+
+// DiscoveryURL returns the endpoint address for url in the given format,
+// for <link rel="alternate"> tags and Link headers.
+func DiscoveryURL(endpoint, pageURL, format string) string {
+	q := url.Values{"url": {pageURL}, "format": {format}}
+	if strings.Contains(endpoint, "?") {
+		return endpoint + "&" + q.Encode()
+	}
+	return endpoint + "?" + q.Encode()
+}
@@ -0,0 +1,78 @@
+// Package payment abstracts the payment provider that sells membership
+// tiers. Only a fake provider is implemented.
+package payment
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+)
+
+var (
+	ErrUnknownCheckout = errors.New("unknown checkout session")
+	ErrNotPaid         = errors.New("checkout has not been paid")
+)
+
+// Checkout is a payment session the buyer completes on the provider's page.
+type Checkout struct {
+	SessionID string
+	URL       string
+	UserID    uint
+	Tier      string
+	Paid      bool
+}
+
+// Provider starts checkouts and reports their outcome.
+type Provider interface {
+	// CreateCheckout starts the purchase of tier by a user.
+	CreateCheckout(userID uint, tier string) (*Checkout, error)
+	// GetCheckout returns a checkout session, with Paid set once the buyer
+	// paid.
+	GetCheckout(sessionID string) (*Checkout, error)
+}
+
+// Fake is an in-memory Provider whose checkouts are paid immediately, for
+// development and tests.
+type Fake struct {
+	mu       sync.Mutex
+	sessions map[string]*Checkout
+}
+
+func NewFake() *Fake {
+	return &Fake{sessions: make(map[string]*Checkout)}
+}
+
+func (f *Fake) CreateCheckout(userID uint, tier string) (*Checkout, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	c := &Checkout{
+		SessionID: "fake_" + hex.EncodeToString(b),
+		UserID:    userID,
+		Tier:      tier,
+		Paid:      true,
+	}
+	c.URL = "https://payments.invalid/checkout/" + c.SessionID
+
+	f.mu.Lock()
+	f.sessions[c.SessionID] = c
+	f.mu.Unlock()
+
+	copied := *c
+	return &copied, nil
+}
+
+func (f *Fake) GetCheckout(sessionID string) (*Checkout, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, ok := f.sessions[sessionID]
+	if !ok {
+		return nil, ErrUnknownCheckout
+	}
+	copied := *c
+	return &copied, nil
+}
@@ -133,6 +133,51 @@ func (c *Client) Consume(
 	return nil
 }
 
+// Subscribe delivers every message published under one of routingKeys to
+// handler, through a private queue that is deleted when the connection
+// closes. Unlike Consume, each subscriber receives its own copy of every
+// message, which suits fan-out to in-process listeners.
+func (c *Client) Subscribe(routingKeys []string, handler func([]byte) error) error {
+	q, err := c.channel.QueueDeclare(
+		"",
+		false, // durable
+		true,  // autoDelete
+		true,  // exclusive
+		false,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range routingKeys {
+		if err := c.channel.QueueBind(q.Name, key, c.exchange, false, nil); err != nil {
+			return err
+		}
+	}
+
+	msgs, err := c.channel.Consume(
+		q.Name,
+		"",
+		true, // autoAck
+		true, // exclusive
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for msg := range msgs {
+			handler(msg.Body)
+		}
+	}()
+
+	return nil
+}
+
 // Close
 func (c *Client) Close() {
 	if c.channel != nil {
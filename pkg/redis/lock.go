@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// acquireScript takes the lease when it is free or already held by the
+// owner, and returns the current holder either way.
+var acquireScript = redis.NewScript(`
+local holder = redis.call("GET", KEYS[1])
+if holder == false or holder == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+	return ARGV[1]
+end
+return holder
+`)
+
+// renewScript extends the lease only if the owner still holds it.
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseScript deletes the lease only if the owner still holds it.
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// AcquireLease takes the lease on key for ttl. It returns the holder, which
+// is owner when the lease was acquired or renewed.
+func (c *Client) AcquireLease(key, owner string, ttl time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return acquireScript.Run(ctx, c.rdb, []string{key}, owner, ttl.Milliseconds()).Text()
+}
+
+// RenewLease extends a lease held by owner. It reports false when the
+// lease expired or belongs to someone else.
+func (c *Client) RenewLease(key, owner string, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return renewScript.Run(ctx, c.rdb, []string{key}, owner, ttl.Milliseconds()).Bool()
+}
+
+// ReleaseLease gives up a lease held by owner.
+func (c *Client) ReleaseLease(key, owner string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return releaseScript.Run(ctx, c.rdb, []string{key}, owner).Bool()
+}
+
+// BreakLease deletes a lease whoever holds it.
+func (c *Client) BreakLease(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return c.rdb.Del(ctx, key).Err()
+}
+
+// LeaseHolder returns the holder of the lease on key and its remaining
+// time, or an empty holder when the lease is free.
+func (c *Client) LeaseHolder(key string) (string, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	holder, err := c.rdb.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", 0, nil
+	}
+	if err != nil {
+		return "", 0, err
+	}
+
+	ttl, err := c.rdb.PTTL(ctx, key).Result()
+	if err != nil {
+		return "", 0, err
+	}
+	return holder, ttl, nil
+}
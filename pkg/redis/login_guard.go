@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// RecordLoginFailure counts a failed login by key, such as an account or a
+// client address, and returns the failures within the last window.
+func (c *Client) RecordLoginFailure(key string, window time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	pipe := c.rdb.TxPipeline()
+	incr := pipe.Incr(ctx, c.loginFailuresKey(key))
+	pipe.Expire(ctx, c.loginFailuresKey(key), window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// BlockLogin holds logins by key back for d.
+func (c *Client) BlockLogin(key string, d time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return c.rdb.Set(ctx, c.loginBlockKey(key), 1, d).Err()
+}
+
+// LoginBlocked returns how long logins by key are still held back, zero if
+// they aren't.
+func (c *Client) LoginBlocked(key string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	ttl, err := c.rdb.PTTL(ctx, c.loginBlockKey(key)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// ClearLoginFailures forgets the failed logins by key and lifts its block.
+func (c *Client) ClearLoginFailures(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return c.rdb.Del(ctx, c.loginFailuresKey(key), c.loginBlockKey(key)).Err()
+}
+
+func (c *Client) loginFailuresKey(key string) string {
+	return "auth:login:failures:" + key
+}
+
+func (c *Client) loginBlockKey(key string) string {
+	return "auth:login:block:" + key
+}
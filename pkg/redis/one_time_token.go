@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SetOneTimeToken stores a single-use token for userID under the hash of
+// the token, for the given purpose such as "reset".
+func (c *Client) SetOneTimeToken(purpose, hash string, userID uint, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return c.rdb.Set(ctx, c.oneTimeTokenKey(purpose, hash), userID, ttl).Err()
+}
+
+// ReplaceOneTimeToken is SetOneTimeToken for tokens a user holds at most
+// one of per purpose: the user's previous token stops working.
+func (c *Client) ReplaceOneTimeToken(purpose, hash string, userID uint, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	latest := fmt.Sprintf("auth:%s:user:%d", purpose, userID)
+	previous, err := c.rdb.SetArgs(ctx, latest, hash, redis.SetArgs{Get: true, TTL: ttl}).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	pipe := c.rdb.TxPipeline()
+	if previous != "" {
+		pipe.Del(ctx, c.oneTimeTokenKey(purpose, previous))
+	}
+	pipe.Set(ctx, c.oneTimeTokenKey(purpose, hash), userID, ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// TakeOneTimeToken consumes a token, returning its user. It reports false
+// for unknown, expired and already used tokens.
+func (c *Client) TakeOneTimeToken(purpose, hash string) (uint, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	val, err := c.rdb.GetDel(ctx, c.oneTimeTokenKey(purpose, hash)).Uint64()
+	if err == redis.Nil {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(val), true, nil
+}
+
+func (c *Client) oneTimeTokenKey(purpose, hash string) string {
+	return fmt.Sprintf("auth:%s:%s", purpose, hash)
+}
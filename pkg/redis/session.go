@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// AddSessionToken records the access token jti as belonging to a login
+// session of userID, so that it can be revoked with the session.
+func (c *Client) AddSessionToken(userID uint, session, jti string, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	pipe := c.rdb.TxPipeline()
+	pipe.SAdd(ctx, c.sessionKey(session), jti)
+	pipe.Expire(ctx, c.sessionKey(session), ttl)
+	pipe.SAdd(ctx, c.userSessionsKey(userID), session)
+	pipe.Expire(ctx, c.userSessionsKey(userID), ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RevokeSession logs a session of userID out: its access tokens and its
+// refresh token family stop working.
+func (c *Client) RevokeSession(userID uint, session string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	jtis, err := c.rdb.SMembers(ctx, c.sessionKey(session)).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := []string{c.sessionKey(session), c.familyKey(session)}
+	for _, jti := range jtis {
+		keys = append(keys, c.tokenKey(jti))
+	}
+	pipe := c.rdb.TxPipeline()
+	pipe.Del(ctx, keys...)
+	pipe.SRem(ctx, c.userSessionsKey(userID), session)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// RevokeUserSessions logs userID out everywhere.
+func (c *Client) RevokeUserSessions(userID uint) error {
+	sessions, err := c.userSessions(userID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if err := c.RevokeSession(userID, session); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) userSessions(userID uint) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return c.rdb.SMembers(ctx, c.userSessionsKey(userID)).Result()
+}
+
+func (c *Client) sessionKey(session string) string {
+	return fmt.Sprintf("auth:session:%s", session)
+}
+
+func (c *Client) userSessionsKey(userID uint) string {
+	return fmt.Sprintf("auth:user:%d:sessions", userID)
+}
@@ -0,0 +1,31 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// Throttle reports whether an action named by key may happen now, allowing
+// it at most once per interval.
+func (c *Client) Throttle(key string, interval time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return c.rdb.SetNX(ctx, "throttle:"+key, 1, interval).Result()
+}
+
+// Allow reports whether an action named by key may happen now, allowing it
+// at most limit times per window. The window starts with the first action.
+func (c *Client) Allow(key string, limit int64, window time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	k := "ratelimit:" + key
+	pipe := c.rdb.TxPipeline()
+	pipe.SetNX(ctx, k, 0, window)
+	incr := pipe.Incr(ctx, k)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+	return incr.Val() <= limit, nil
+}
@@ -0,0 +1,63 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Outcomes of RotateTokenFamily.
+const (
+	RotateOK      = 1  // the token was current and has been replaced
+	RotateUnknown = 0  // the family expired or was revoked
+	RotateReused  = -1 // an older token was presented; the family is revoked
+)
+
+// rotateScript replaces the family's current token ID when the presented
+// one is current. Presenting any other token of a live family means it was
+// used twice, so the whole family is deleted.
+var rotateScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current == false then
+	return 0
+end
+if current == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+	return 1
+end
+redis.call("DEL", KEYS[1])
+return -1
+`)
+
+// StartTokenFamily records jti as the current refresh token of a new token
+// family.
+func (c *Client) StartTokenFamily(family, jti string, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return c.rdb.Set(ctx, c.familyKey(family), jti, ttl).Err()
+}
+
+// RotateTokenFamily swaps the family's current refresh token oldJTI for
+// newJTI, returning RotateOK, RotateUnknown or RotateReused.
+func (c *Client) RotateTokenFamily(family, oldJTI, newJTI string, ttl time.Duration) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return rotateScript.Run(ctx, c.rdb, []string{c.familyKey(family)}, oldJTI, newJTI, ttl.Milliseconds()).Int()
+}
+
+// RevokeTokenFamily ends a token family; none of its refresh tokens work
+// any more.
+func (c *Client) RevokeTokenFamily(family string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return c.rdb.Del(ctx, c.familyKey(family)).Err()
+}
+
+func (c *Client) familyKey(family string) string {
+	return fmt.Sprintf("auth:refresh:%s", family)
+}
@@ -0,0 +1,96 @@
+// Package seo renders the <head> markup that search engines and social
+// networks read to preview a page: meta description, robots, canonical link,
+// OpenGraph and Twitter card tags, and a schema.org JSON-LD block.
+package seo
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"time"
+)
+
+// Meta describes an article page.
+type Meta struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	URL         string     `json:"url"`
+	Canonical   string     `json:"canonical"`
+	Image       string     `json:"image,omitempty"`
+	SiteName    string     `json:"site_name"`
+	Locale      string     `json:"locale"`
+	Tags        []string   `json:"tags,omitempty"`
+	NoIndex     bool       `json:"noindex"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	ModifiedAt  time.Time  `json:"modified_at"`
+	// oEmbed discovery endpoints for the page, if any
+	OEmbedJSON string `json:"oembed_json,omitempty"`
+	OEmbedXML  string `json:"oembed_xml,omitempty"`
+	// Webmention endpoint accepting mentions of the page, if any
+	Webmention string `json:"webmention,omitempty"`
+}
+
+// BlogPosting returns the schema.org BlogPosting object for m.
+func (m Meta) BlogPosting() map[string]any {
+	ld := map[string]any{
+		"@context":         "https://schema.org",
+		"@type":            "BlogPosting",
+		"headline":         m.Title,
+		"description":      m.Description,
+		"url":              m.URL,
+		"mainEntityOfPage": m.Canonical,
+		"inLanguage":       m.Locale,
+		"dateModified":     m.ModifiedAt.Format(time.RFC3339),
+		"publisher":        map[string]any{"@type": "Organization", "name": m.SiteName},
+	}
+	if m.Image != "" {
+		ld["image"] = m.Image
+	}
+	if len(m.Tags) > 0 {
+		ld["keywords"] = m.Tags
+	}
+	if m.PublishedAt != nil {
+		ld["datePublished"] = m.PublishedAt.Format(time.RFC3339)
+	}
+	return ld
+}
+
+var head = template.Must(template.New("head").Parse(`<title>{{.Title}}</title>
+<meta name="description" content="{{.Description}}">
+{{if .NoIndex}}<meta name="robots" content="noindex">
+{{end}}<link rel="canonical" href="{{.Canonical}}">
+<meta property="og:type" content="article">
+<meta property="og:title" content="{{.Title}}">
+<meta property="og:description" content="{{.Description}}">
+<meta property="og:url" content="{{.Canonical}}">
+<meta property="og:site_name" content="{{.SiteName}}">
+<meta property="og:locale" content="{{.Locale}}">
+{{if .Image}}<meta property="og:image" content="{{.Image}}">
+{{end}}{{if .PublishedAt}}<meta property="article:published_time" content="{{.PublishedAt.Format "2006-01-02T15:04:05Z07:00"}}">
+{{end}}<meta property="article:modified_time" content="{{.ModifiedAt.Format "2006-01-02T15:04:05Z07:00"}}">
+{{range .Tags}}<meta property="article:tag" content="{{.}}">
+{{end}}<meta name="twitter:card" content="{{if .Image}}summary_large_image{{else}}summary{{end}}">
+<meta name="twitter:title" content="{{.Title}}">
+<meta name="twitter:description" content="{{.Description}}">
+{{if .Image}}<meta name="twitter:image" content="{{.Image}}">
+{{end}}{{if .OEmbedJSON}}<link rel="alternate" type="application/json+oembed" href="{{.OEmbedJSON}}" title="{{.Title}}">
+{{end}}{{if .OEmbedXML}}<link rel="alternate" type="text/xml+oembed" href="{{.OEmbedXML}}" title="{{.Title}}">
+{{end}}{{if .Webmention}}<link rel="webmention" href="{{.Webmention}}">
+{{end}}<script type="application/ld+json">{{.JSONLD}}</script>
+`))
+
+// Head renders m as a <head> fragment.
+func Head(m Meta) (string, error) {
+	// json.Marshal escapes <, > and &, so the output is safe inside <script>.
+	ld, err := json.Marshal(m.BlogPosting())
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = head.Execute(&buf, struct {
+		Meta
+		JSONLD template.JS
+	}{m, template.JS(ld)})
+	return buf.String(), err
+}
@@ -0,0 +1,227 @@
+// Package webmention implements sending and verifying Webmentions
+// (https://www.w3.org/TR/webmention/): endpoint discovery, the notification
+// request and checking that a source document links to its target.
+package webmention
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// maxDocumentSize caps the documents fetched for discovery and verification.
+const maxDocumentSize = 1 << 20
+
+var (
+	// ErrNoEndpoint is returned by Discover for targets that do not accept
+	// Webmentions.
+	ErrNoEndpoint = errors.New("target has no webmention endpoint")
+	// ErrNoLink is returned by Verify when the source does not link to the
+	// target (any more).
+	ErrNoLink = errors.New("source does not link to target")
+)
+
+// Client talks to remote sites over HTTP. Sources, targets and endpoints
+// come from untrusted input, so h should be a safehttp client.
+type Client struct {
+	http *http.Client
+}
+
+func NewClient(h *http.Client) *Client {
+	return &Client{http: h}
+}
+
+// Discover returns the Webmention endpoint of target: the first
+// rel="webmention" of its Link headers, or else of its HTML <link> and <a>
+// elements, resolved against the final URL after redirects.
+func (c *Client) Discover(target string) (string, error) {
+	resp, err := c.get(target)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	base := resp.Request.URL
+	for _, h := range resp.Header.Values("Link") {
+		if href, ok := linkHeaderWebmention(h); ok {
+			return resolve(base, href)
+		}
+	}
+	if !strings.Contains(resp.Header.Get("Content-Type"), "html") {
+		return "", ErrNoEndpoint
+	}
+
+	doc, err := html.Parse(io.LimitReader(resp.Body, maxDocumentSize))
+	if err != nil {
+		return "", err
+	}
+	for n := range doc.Descendants() {
+		if n.Type != html.ElementNode || (n.Data != "link" && n.Data != "a") {
+			continue
+		}
+		if href, ok := attr(n, "href"); ok && hasRel(n, "webmention") {
+			return resolve(base, href)
+		}
+	}
+	return "", ErrNoEndpoint
+}
+
+// Send notifies endpoint that source mentions target.
+func (c *Client) Send(endpoint, source, target string) error {
+	resp, err := c.http.PostForm(endpoint, url.Values{"source": {source}, "target": {target}})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxDocumentSize))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sending webmention to %s: %s", endpoint, resp.Status)
+	}
+	return nil
+}
+
+// Mention is what a verified source document says about its target.
+type Mention struct {
+	Title string // the source's <title>, if any
+}
+
+// Verify fetches source and checks that it links to target. A source that
+// is gone (404 or 410) or no longer links returns ErrNoLink.
+func (c *Client) Verify(source, target string) (*Mention, error) {
+	resp, err := c.get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, ErrNoLink
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", source, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDocumentSize))
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(resp.Header.Get("Content-Type"), "html") {
+		// Plain text and other documents only have to contain the URL.
+		if strings.Contains(string(body), target) {
+			return &Mention{}, nil
+		}
+		return nil, ErrNoLink
+	}
+
+	doc, err := html.Parse(strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+	mention, linked := &Mention{}, false
+	for n := range doc.Descendants() {
+		if n.Type != html.ElementNode {
+			continue
+		}
+		if n.Data == "title" && mention.Title == "" && n.FirstChild != nil {
+			mention.Title = strings.TrimSpace(n.FirstChild.Data)
+		}
+		for _, key := range []string{"href", "src"} {
+			if v, ok := attr(n, key); ok {
+				if abs, err := resolve(resp.Request.URL, v); err == nil && abs == target {
+					linked = true
+				}
+			}
+		}
+	}
+	if !linked {
+		return nil, ErrNoLink
+	}
+	return mention, nil
+}
+
+func (c *Client) get(u string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html, */*;q=0.5")
+	return c.http.Do(req)
+}
+
+var (
+	markdownLink = regexp.MustCompile(`\]\((https?://[^\s)]+)`)
+	hrefAttr     = regexp.MustCompile(`(?i)href\s*=\s*["'](https?://[^"']+)["']`)
+)
+
+// Links returns the distinct absolute http(s) links of markdown or HTML
+// content, in order of appearance.
+func Links(content string) []string {
+	var links []string
+	seen := make(map[string]bool)
+	for _, re := range []*regexp.Regexp{markdownLink, hrefAttr} {
+		for _, m := range re.FindAllStringSubmatch(content, -1) {
+			link := html.UnescapeString(m[1])
+			if !seen[link] {
+				seen[link] = true
+				links = append(links, link)
+			}
+		}
+	}
+	return links
+}
+
+// linkHeaderWebmention finds a rel="webmention" target in a Link header.
+func linkHeaderWebmention(header string) (string, bool) {
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+		href := strings.TrimSpace(parts[0])
+		if !strings.HasPrefix(href, "<") || !strings.HasSuffix(href, ">") {
+			continue
+		}
+		for _, p := range parts[1:] {
+			k, v, _ := strings.Cut(strings.TrimSpace(p), "=")
+			if strings.EqualFold(k, "rel") && containsFold(strings.Fields(strings.Trim(v, `"`)), "webmention") {
+				return href[1 : len(href)-1], true
+			}
+		}
+	}
+	return "", false
+}
+
+func attr(n *html.Node, key string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+func hasRel(n *html.Node, rel string) bool {
+	v, _ := attr(n, "rel")
+	return containsFold(strings.Fields(v), rel)
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve makes href absolute against base. An empty href is base itself.
+func resolve(base *url.URL, href string) (string, error) {
+	ref, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(ref).String(), nil
+}
@@ -0,0 +1,167 @@
+// Package wxr reads WordPress eXtended RSS (WXR) export files.
+package wxr
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+)
+
+// Export is the channel of a WXR file. Elements in the wp: namespace are
+// matched by local name only, so exports of every WXR version decode.
+// The excerpt namespace carries the WXR version too; see Item.Excerpt.
+type Export struct {
+	Title   string   `xml:"channel>title"`
+	Link    string   `xml:"channel>link"`
+	Authors []Author `xml:"channel>author"`
+	Items   []Item   `xml:"channel>item"`
+}
+
+type Author struct {
+	ID          string `xml:"author_id"`
+	Login       string `xml:"author_login"`
+	Email       string `xml:"author_email"`
+	DisplayName string `xml:"author_display_name"`
+}
+
+type Item struct {
+	Title   string `xml:"title"`
+	Link    string `xml:"link"`
+	PubDate string `xml:"pubDate"`
+	Creator string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Content string `xml:"-"`
+	Excerpt string `xml:"-"`
+	// Encoded holds both content:encoded and excerpt:encoded, which share
+	// a local name. Parse sorts them into Content and Excerpt by namespace,
+	// accepting the excerpt namespace of any WXR version.
+	Encoded    []Encoded  `xml:"encoded"`
+	PostID     string     `xml:"post_id"`
+	PostDate   string     `xml:"post_date"`
+	PostDateGM string     `xml:"post_date_gmt"`
+	PostName   string     `xml:"post_name"`
+	Status     string     `xml:"status"`
+	PostType   string     `xml:"post_type"`
+	Categories []Category `xml:"category"`
+	Comments   []Comment  `xml:"comment"`
+}
+
+// Encoded is a namespaced <encoded> element of an item.
+type Encoded struct {
+	XMLName xml.Name
+	Text    string `xml:",chardata"`
+}
+
+type Category struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+type Comment struct {
+	ID          string `xml:"comment_id"`
+	Author      string `xml:"comment_author"`
+	AuthorEmail string `xml:"comment_author_email"`
+	AuthorURL   string `xml:"comment_author_url"`
+	Date        string `xml:"comment_date"`
+	DateGMT     string `xml:"comment_date_gmt"`
+	Content     string `xml:"comment_content"`
+	Approved    string `xml:"comment_approved"`
+	Type        string `xml:"comment_type"`
+	Parent      string `xml:"comment_parent"`
+	UserID      string `xml:"comment_user_id"`
+}
+
+// Parse decodes a WXR document.
+func Parse(r io.Reader) (*Export, error) {
+	var exp Export
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	if err := dec.Decode(&exp); err != nil {
+		return nil, err
+	}
+
+	for i := range exp.Items {
+		item := &exp.Items[i]
+		for _, e := range item.Encoded {
+			switch {
+			case isContentSpace(e.XMLName.Space):
+				item.Content = e.Text
+			case isExcerptSpace(e.XMLName.Space):
+				item.Excerpt = e.Text
+			}
+		}
+	}
+	return &exp, nil
+}
+
+// isContentSpace matches the content: namespace, or the bare prefix when an
+// export forgets to declare it.
+func isContentSpace(space string) bool {
+	return space == "http://purl.org/rss/1.0/modules/content/" || space == "content"
+}
+
+// isExcerptSpace matches http://wordpress.org/export/{version}/excerpt/ or
+// an undeclared excerpt: prefix.
+func isExcerptSpace(space string) bool {
+	return space == "excerpt" ||
+		strings.HasPrefix(space, "http://wordpress.org/export/") && strings.HasSuffix(space, "/excerpt/")
+}
+
+// Tags returns the post_tag terms of the item.
+func (i *Item) Tags() []string {
+	return i.terms("post_tag")
+}
+
+// CategoryNames returns the category terms of the item.
+func (i *Item) CategoryNames() []string {
+	return i.terms("category")
+}
+
+func (i *Item) terms(domain string) []string {
+	var out []string
+	for _, c := range i.Categories {
+		if c.Domain == domain {
+			out = append(out, strings.TrimSpace(c.Name))
+		}
+	}
+	return out
+}
+
+// Published reports the item's original publication time, preferring the
+// GMT timestamp WordPress keeps alongside the local one.
+func (i *Item) Published() time.Time {
+	if t, ok := parseWPDate(i.PostDateGM); ok {
+		return t
+	}
+	if t, err := time.Parse(time.RFC1123Z, i.PubDate); err == nil {
+		return t
+	}
+	if t, ok := parseWPDate(i.PostDate); ok {
+		return t
+	}
+	return time.Time{}
+}
+
+// Published reports when the comment was written.
+func (c *Comment) Published() time.Time {
+	if t, ok := parseWPDate(c.DateGMT); ok {
+		return t
+	}
+	t, _ := parseWPDate(c.Date)
+	return t
+}
+
+// parseWPDate parses "2006-01-02 15:04:05" and rejects the zero date
+// WordPress writes for unpublished drafts.
+func parseWPDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.HasPrefix(s, "0000-00-00") {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.DateTime, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
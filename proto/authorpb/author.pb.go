@@ -21,9 +21,12 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// The authenticated caller becomes an author; user_id is ignored.
 type BecomeAuthorRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in author.proto.
+	UserId        uint64 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // display name for the author's profile, optional
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -58,6 +61,7 @@ func (*BecomeAuthorRequest) Descriptor() ([]byte, []int) {
 	return file_author_proto_rawDescGZIP(), []int{0}
 }
 
+// Deprecated: Marked as deprecated in author.proto.
 func (x *BecomeAuthorRequest) GetUserId() uint64 {
 	if x != nil {
 		return x.UserId
@@ -65,6 +69,13 @@ func (x *BecomeAuthorRequest) GetUserId() uint64 {
 	return 0
 }
 
+func (x *BecomeAuthorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
 type AuthorResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -209,9 +220,10 @@ var File_author_proto protoreflect.FileDescriptor
 
 const file_author_proto_rawDesc = "" +
 	"\n" +
-	"\fauthor.proto\x12\x06author\".\n" +
-	"\x13BecomeAuthorRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x04R\x06userId\"9\n" +
+	"\fauthor.proto\x12\x06author\"F\n" +
+	"\x13BecomeAuthorRequest\x12\x1b\n" +
+	"\auser_id\x18\x01 \x01(\x04B\x02\x18\x01R\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"9\n" +
 	"\x0eAuthorResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x04R\x06userId\"0\n" +
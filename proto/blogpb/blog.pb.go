@@ -9,6 +9,7 @@ package blogpb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,11 +22,15 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// visibility is one of PUBLIC (default), UNLISTED, MEMBERS or PRIVATE. The
+// post is created by the authenticated caller; author_id is ignored.
 type CreatePostRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AuthorId      uint64                 `protobuf:"varint,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in blog.proto.
+	AuthorId      uint64 `protobuf:"varint,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Title         string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Visibility    string `protobuf:"bytes,4,opt,name=visibility,proto3" json:"visibility,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -60,6 +65,7 @@ func (*CreatePostRequest) Descriptor() ([]byte, []int) {
 	return file_blog_proto_rawDescGZIP(), []int{0}
 }
 
+// Deprecated: Marked as deprecated in blog.proto.
 func (x *CreatePostRequest) GetAuthorId() uint64 {
 	if x != nil {
 		return x.AuthorId
@@ -81,6 +87,13 @@ func (x *CreatePostRequest) GetContent() string {
 	return ""
 }
 
+func (x *CreatePostRequest) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
 type BlogResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -141,23 +154,1639 @@ func (x *BlogResponse) GetContent() string {
 	return ""
 }
 
-var File_blog_proto protoreflect.FileDescriptor
+type Post struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AuthorId         uint64                 `protobuf:"varint,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Slug             string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	Locale           string                 `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	AvailableLocales []string               `protobuf:"bytes,5,rep,name=available_locales,json=availableLocales,proto3" json:"available_locales,omitempty"`
+	Title            string                 `protobuf:"bytes,6,opt,name=title,proto3" json:"title,omitempty"`
+	Content          string                 `protobuf:"bytes,7,opt,name=content,proto3" json:"content,omitempty"`
+	Tags             []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	Categories       []string               `protobuf:"bytes,9,rep,name=categories,proto3" json:"categories,omitempty"`
+	PublishedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version          uint64                 `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
+	Visibility       string                 `protobuf:"bytes,14,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Status           string                 `protobuf:"bytes,15,opt,name=status,proto3" json:"status,omitempty"`
+	Seo              *PostSEO               `protobuf:"bytes,16,opt,name=seo,proto3" json:"seo,omitempty"`
+	RequiredTier     string                 `protobuf:"bytes,17,opt,name=required_tier,json=requiredTier,proto3" json:"required_tier,omitempty"` // empty for free posts
+	Paywalled        bool                   `protobuf:"varint,18,opt,name=paywalled,proto3" json:"paywalled,omitempty"`                          // content stops at the paywall marker
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
 
-const file_blog_proto_rawDesc = "" +
-	"\n" +
-	"\n" +
-	"blog.proto\x12\x04blog\"`\n" +
-	"\x11CreatePostRequest\x12\x1b\n" +
-	"\tauthor_id\x18\x01 \x01(\x04R\bauthorId\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
-	"\acontent\x18\x03 \x01(\tR\acontent\"N\n" +
-	"\fBlogResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
-	"\acontent\x18\x03 \x01(\tR\acontent2H\n" +
-	"\vBlogService\x129\n" +
+func (x *Post) Reset() {
+	*x = Post{}
+	mi := &file_blog_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Post) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Post) ProtoMessage() {}
+
+func (x *Post) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Post.ProtoReflect.Descriptor instead.
+func (*Post) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Post) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Post) GetAuthorId() uint64 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *Post) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Post) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *Post) GetAvailableLocales() []string {
+	if x != nil {
+		return x.AvailableLocales
+	}
+	return nil
+}
+
+func (x *Post) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Post) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Post) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Post) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Post) GetPublishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return nil
+}
+
+func (x *Post) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Post) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Post) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Post) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *Post) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Post) GetSeo() *PostSEO {
+	if x != nil {
+		return x.Seo
+	}
+	return nil
+}
+
+func (x *Post) GetRequiredTier() string {
+	if x != nil {
+		return x.RequiredTier
+	}
+	return ""
+}
+
+func (x *Post) GetPaywalled() bool {
+	if x != nil {
+		return x.Paywalled
+	}
+	return false
+}
+
+// Empty fields are derived from the post: the description from an excerpt
+// and the social image from the first image in the content.
+type PostSEO struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MetaDescription string                 `protobuf:"bytes,1,opt,name=meta_description,json=metaDescription,proto3" json:"meta_description,omitempty"`
+	CanonicalUrl    string                 `protobuf:"bytes,2,opt,name=canonical_url,json=canonicalUrl,proto3" json:"canonical_url,omitempty"`
+	SocialImage     string                 `protobuf:"bytes,3,opt,name=social_image,json=socialImage,proto3" json:"social_image,omitempty"`
+	Noindex         bool                   `protobuf:"varint,4,opt,name=noindex,proto3" json:"noindex,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PostSEO) Reset() {
+	*x = PostSEO{}
+	mi := &file_blog_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostSEO) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostSEO) ProtoMessage() {}
+
+func (x *PostSEO) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostSEO.ProtoReflect.Descriptor instead.
+func (*PostSEO) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PostSEO) GetMetaDescription() string {
+	if x != nil {
+		return x.MetaDescription
+	}
+	return ""
+}
+
+func (x *PostSEO) GetCanonicalUrl() string {
+	if x != nil {
+		return x.CanonicalUrl
+	}
+	return ""
+}
+
+func (x *PostSEO) GetSocialImage() string {
+	if x != nil {
+		return x.SocialImage
+	}
+	return ""
+}
+
+func (x *PostSEO) GetNoindex() bool {
+	if x != nil {
+		return x.Noindex
+	}
+	return false
+}
+
+// locale takes a single locale or an Accept-Language style list.
+type GetPostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Locale        string                 `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostRequest) Reset() {
+	*x = GetPostRequest{}
+	mi := &file_blog_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostRequest) ProtoMessage() {}
+
+func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostRequest.ProtoReflect.Descriptor instead.
+func (*GetPostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetPostRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetPostRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *GetPostRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+// expected_version is the version the edit started from. Stale writes fail
+// with FAILED_PRECONDITION. An empty tags list or visibility, or an unset
+// seo, leaves the field unchanged. The update is made by the authenticated
+// caller; user_id is ignored.
+type UpdatePostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in blog.proto.
+	UserId          uint64   `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PostId          uint64   `protobuf:"varint,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	ExpectedVersion uint64   `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	Title           string   `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Content         string   `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	Tags            []string `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Visibility      string   `protobuf:"bytes,7,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Seo             *PostSEO `protobuf:"bytes,8,opt,name=seo,proto3" json:"seo,omitempty"`
+	RequiredTier    *string  `protobuf:"bytes,9,opt,name=required_tier,json=requiredTier,proto3,oneof" json:"required_tier,omitempty"` // unset leaves it unchanged, empty makes the post free
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdatePostRequest) Reset() {
+	*x = UpdatePostRequest{}
+	mi := &file_blog_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePostRequest) ProtoMessage() {}
+
+func (x *UpdatePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePostRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{5}
+}
+
+// Deprecated: Marked as deprecated in blog.proto.
+func (x *UpdatePostRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UpdatePostRequest) GetPostId() uint64 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *UpdatePostRequest) GetExpectedVersion() uint64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdatePostRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdatePostRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *UpdatePostRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdatePostRequest) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *UpdatePostRequest) GetSeo() *PostSEO {
+	if x != nil {
+		return x.Seo
+	}
+	return nil
+}
+
+func (x *UpdatePostRequest) GetRequiredTier() string {
+	if x != nil && x.RequiredTier != nil {
+		return *x.RequiredTier
+	}
+	return ""
+}
+
+type ListPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      uint64                 `protobuf:"varint,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Locale        string                 `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPostsRequest) Reset() {
+	*x = ListPostsRequest{}
+	mi := &file_blog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostsRequest) ProtoMessage() {}
+
+func (x *ListPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostsRequest.ProtoReflect.Descriptor instead.
+func (*ListPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListPostsRequest) GetAuthorId() uint64 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *ListPostsRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListPostsRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ListPostsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPostsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListPostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Posts         []*Post                `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPostsResponse) Reset() {
+	*x = ListPostsResponse{}
+	mi := &file_blog_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostsResponse) ProtoMessage() {}
+
+func (x *ListPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostsResponse.ProtoReflect.Descriptor instead.
+func (*ListPostsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListPostsResponse) GetPosts() []*Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+// The translation is made by the authenticated caller; user_id is ignored.
+type SetTranslationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in blog.proto.
+	UserId        uint64 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PostId        uint64 `protobuf:"varint,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Locale        string `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	Title         string `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTranslationRequest) Reset() {
+	*x = SetTranslationRequest{}
+	mi := &file_blog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTranslationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTranslationRequest) ProtoMessage() {}
+
+func (x *SetTranslationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTranslationRequest.ProtoReflect.Descriptor instead.
+func (*SetTranslationRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{8}
+}
+
+// Deprecated: Marked as deprecated in blog.proto.
+func (x *SetTranslationRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetTranslationRequest) GetPostId() uint64 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *SetTranslationRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *SetTranslationRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SetTranslationRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// expected_version is optional; when set, the delete fails with
+// FAILED_PRECONDITION if the post changed since. The post is deleted by the
+// authenticated caller; user_id is ignored.
+type DeletePostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in blog.proto.
+	UserId          uint64 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PostId          uint64 `protobuf:"varint,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	ExpectedVersion uint64 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeletePostRequest) Reset() {
+	*x = DeletePostRequest{}
+	mi := &file_blog_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePostRequest) ProtoMessage() {}
+
+func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePostRequest.ProtoReflect.Descriptor instead.
+func (*DeletePostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{9}
+}
+
+// Deprecated: Marked as deprecated in blog.proto.
+func (x *DeletePostRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DeletePostRequest) GetPostId() uint64 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *DeletePostRequest) GetExpectedVersion() uint64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeletePostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePostResponse) Reset() {
+	*x = DeletePostResponse{}
+	mi := &file_blog_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePostResponse) ProtoMessage() {}
+
+func (x *DeletePostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePostResponse.ProtoReflect.Descriptor instead.
+func (*DeletePostResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeletePostResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// resume_token is the token of the last event received; events after it are
+// replayed before live events are streamed. Leave it empty to only receive
+// new events.
+type WatchPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      uint64                 `protobuf:"varint,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	ResumeToken   string                 `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPostsRequest) Reset() {
+	*x = WatchPostsRequest{}
+	mi := &file_blog_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPostsRequest) ProtoMessage() {}
+
+func (x *WatchPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPostsRequest.ProtoReflect.Descriptor instead.
+func (*WatchPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WatchPostsRequest) GetAuthorId() uint64 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *WatchPostsRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *WatchPostsRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type PostEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // blog.created, blog.updated, blog.deleted or blog.published
+	PostId        uint64                 `protobuf:"varint,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	AuthorId      uint64                 `protobuf:"varint,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Post          *Post                  `protobuf:"bytes,4,opt,name=post,proto3" json:"post,omitempty"` // unset for blog.deleted
+	ResumeToken   string                 `protobuf:"bytes,5,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostEvent) Reset() {
+	*x = PostEvent{}
+	mi := &file_blog_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostEvent) ProtoMessage() {}
+
+func (x *PostEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostEvent.ProtoReflect.Descriptor instead.
+func (*PostEvent) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PostEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PostEvent) GetPostId() uint64 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *PostEvent) GetAuthorId() uint64 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *PostEvent) GetPost() *Post {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *PostEvent) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *PostEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// PostActionRequest acts on a post as the authenticated caller; user_id is
+// ignored.
+type PostActionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in blog.proto.
+	UserId        uint64 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PostId        uint64 `protobuf:"varint,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostActionRequest) Reset() {
+	*x = PostActionRequest{}
+	mi := &file_blog_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostActionRequest) ProtoMessage() {}
+
+func (x *PostActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostActionRequest.ProtoReflect.Descriptor instead.
+func (*PostActionRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{13}
+}
+
+// Deprecated: Marked as deprecated in blog.proto.
+func (x *PostActionRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PostActionRequest) GetPostId() uint64 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+type ReviewComment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quote         string                 `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewComment) Reset() {
+	*x = ReviewComment{}
+	mi := &file_blog_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewComment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewComment) ProtoMessage() {}
+
+func (x *ReviewComment) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewComment.ProtoReflect.Descriptor instead.
+func (*ReviewComment) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReviewComment) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *ReviewComment) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ReviewComment) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+// decision is APPROVE, REQUEST_CHANGES or REJECT. The editor is the caller
+// identified by the authorization metadata.
+type ReviewPostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        uint64                 `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Decision      string                 `protobuf:"bytes,2,opt,name=decision,proto3" json:"decision,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	Comments      []*ReviewComment       `protobuf:"bytes,4,rep,name=comments,proto3" json:"comments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewPostRequest) Reset() {
+	*x = ReviewPostRequest{}
+	mi := &file_blog_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPostRequest) ProtoMessage() {}
+
+func (x *ReviewPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPostRequest.ProtoReflect.Descriptor instead.
+func (*ReviewPostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReviewPostRequest) GetPostId() uint64 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *ReviewPostRequest) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *ReviewPostRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *ReviewPostRequest) GetComments() []*ReviewComment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+type ListReviewQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewQueueRequest) Reset() {
+	*x = ListReviewQueueRequest{}
+	mi := &file_blog_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewQueueRequest) ProtoMessage() {}
+
+func (x *ListReviewQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewQueueRequest.ProtoReflect.Descriptor instead.
+func (*ListReviewQueueRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{16}
+}
+
+type EditLock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        uint64                 `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditLock) Reset() {
+	*x = EditLock{}
+	mi := &file_blog_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditLock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditLock) ProtoMessage() {}
+
+func (x *EditLock) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditLock.ProtoReflect.Descriptor instead.
+func (*EditLock) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EditLock) GetPostId() uint64 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *EditLock) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *EditLock) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// acquired is false when another user holds the lock, which is then
+// described by lock. lock is unset when nobody is editing the post.
+type EditLockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Acquired      bool                   `protobuf:"varint,1,opt,name=acquired,proto3" json:"acquired,omitempty"`
+	Lock          *EditLock              `protobuf:"bytes,2,opt,name=lock,proto3" json:"lock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditLockResponse) Reset() {
+	*x = EditLockResponse{}
+	mi := &file_blog_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditLockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditLockResponse) ProtoMessage() {}
+
+func (x *EditLockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditLockResponse.ProtoReflect.Descriptor instead.
+func (*EditLockResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *EditLockResponse) GetAcquired() bool {
+	if x != nil {
+		return x.Acquired
+	}
+	return false
+}
+
+func (x *EditLockResponse) GetLock() *EditLock {
+	if x != nil {
+		return x.Lock
+	}
+	return nil
+}
+
+// Releases the lock of the caller identified by the authorization metadata;
+// user_id is ignored. With force the caller, who must be an editor, breaks
+// the lock whoever holds it.
+type ReleaseEditLockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in blog.proto.
+	UserId        uint64 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PostId        uint64 `protobuf:"varint,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Force         bool   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseEditLockRequest) Reset() {
+	*x = ReleaseEditLockRequest{}
+	mi := &file_blog_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseEditLockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseEditLockRequest) ProtoMessage() {}
+
+func (x *ReleaseEditLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseEditLockRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseEditLockRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{19}
+}
+
+// Deprecated: Marked as deprecated in blog.proto.
+func (x *ReleaseEditLockRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ReleaseEditLockRequest) GetPostId() uint64 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *ReleaseEditLockRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type GetEditLockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        uint64                 `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEditLockRequest) Reset() {
+	*x = GetEditLockRequest{}
+	mi := &file_blog_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEditLockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEditLockRequest) ProtoMessage() {}
+
+func (x *GetEditLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEditLockRequest.ProtoReflect.Descriptor instead.
+func (*GetEditLockRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetEditLockRequest) GetPostId() uint64 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+// post_id is 0 for a post that was not created yet. Autosaving again within
+// the autosave interval fails with RESOURCE_EXHAUSTED. Autosaves belong to
+// the authenticated caller; user_id is ignored.
+type AutosaveRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in blog.proto.
+	UserId        uint64 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PostId        uint64 `protobuf:"varint,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Title         string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutosaveRequest) Reset() {
+	*x = AutosaveRequest{}
+	mi := &file_blog_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutosaveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutosaveRequest) ProtoMessage() {}
+
+func (x *AutosaveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutosaveRequest.ProtoReflect.Descriptor instead.
+func (*AutosaveRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{21}
+}
+
+// Deprecated: Marked as deprecated in blog.proto.
+func (x *AutosaveRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AutosaveRequest) GetPostId() uint64 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *AutosaveRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *AutosaveRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// saved is false when the content matched the previous autosave. From
+// GetAutosave, title and content are empty when there is nothing to restore.
+type AutosaveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Saved         bool                   `protobuf:"varint,1,opt,name=saved,proto3" json:"saved,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	SavedAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=saved_at,json=savedAt,proto3" json:"saved_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutosaveResponse) Reset() {
+	*x = AutosaveResponse{}
+	mi := &file_blog_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutosaveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutosaveResponse) ProtoMessage() {}
+
+func (x *AutosaveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutosaveResponse.ProtoReflect.Descriptor instead.
+func (*AutosaveResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AutosaveResponse) GetSaved() bool {
+	if x != nil {
+		return x.Saved
+	}
+	return false
+}
+
+func (x *AutosaveResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *AutosaveResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *AutosaveResponse) GetSavedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SavedAt
+	}
+	return nil
+}
+
+var File_blog_proto protoreflect.FileDescriptor
+
+const file_blog_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"blog.proto\x12\x04blog\x1a\x1fgoogle/protobuf/timestamp.proto\"\x84\x01\n" +
+	"\x11CreatePostRequest\x12\x1f\n" +
+	"\tauthor_id\x18\x01 \x01(\x04B\x02\x18\x01R\bauthorId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\x04 \x01(\tR\n" +
+	"visibility\"N\n" +
+	"\fBlogResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\"\xdb\x04\n" +
+	"\x04Post\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\x04R\bauthorId\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\x12+\n" +
+	"\x11available_locales\x18\x05 \x03(\tR\x10availableLocales\x12\x14\n" +
+	"\x05title\x18\x06 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\a \x01(\tR\acontent\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12\x1e\n" +
+	"\n" +
+	"categories\x18\t \x03(\tR\n" +
+	"categories\x12=\n" +
+	"\fpublished_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vpublishedAt\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\r \x01(\x04R\aversion\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\x0e \x01(\tR\n" +
+	"visibility\x12\x16\n" +
+	"\x06status\x18\x0f \x01(\tR\x06status\x12\x1f\n" +
+	"\x03seo\x18\x10 \x01(\v2\r.blog.PostSEOR\x03seo\x12#\n" +
+	"\rrequired_tier\x18\x11 \x01(\tR\frequiredTier\x12\x1c\n" +
+	"\tpaywalled\x18\x12 \x01(\bR\tpaywalled\"\x96\x01\n" +
+	"\aPostSEO\x12)\n" +
+	"\x10meta_description\x18\x01 \x01(\tR\x0fmetaDescription\x12#\n" +
+	"\rcanonical_url\x18\x02 \x01(\tR\fcanonicalUrl\x12!\n" +
+	"\fsocial_image\x18\x03 \x01(\tR\vsocialImage\x12\x18\n" +
+	"\anoindex\x18\x04 \x01(\bR\anoindex\"L\n" +
+	"\x0eGetPostRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\"\xb5\x02\n" +
+	"\x11UpdatePostRequest\x12\x1b\n" +
+	"\auser_id\x18\x01 \x01(\x04B\x02\x18\x01R\x06userId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\x04R\x06postId\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x04R\x0fexpectedVersion\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\a \x01(\tR\n" +
+	"visibility\x12\x1f\n" +
+	"\x03seo\x18\b \x01(\v2\r.blog.PostSEOR\x03seo\x12(\n" +
+	"\rrequired_tier\x18\t \x01(\tH\x00R\frequiredTier\x88\x01\x01B\x10\n" +
+	"\x0e_required_tier\"\x87\x01\n" +
+	"\x10ListPostsRequest\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\x04R\bauthorId\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\"5\n" +
+	"\x11ListPostsResponse\x12 \n" +
+	"\x05posts\x18\x01 \x03(\v2\n" +
+	".blog.PostR\x05posts\"\x95\x01\n" +
+	"\x15SetTranslationRequest\x12\x1b\n" +
+	"\auser_id\x18\x01 \x01(\x04B\x02\x18\x01R\x06userId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\x04R\x06postId\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\"t\n" +
+	"\x11DeletePostRequest\x12\x1b\n" +
+	"\auser_id\x18\x01 \x01(\x04B\x02\x18\x01R\x06userId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\x04R\x06postId\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x04R\x0fexpectedVersion\".\n" +
+	"\x12DeletePostResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"e\n" +
+	"\x11WatchPostsRequest\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\x04R\bauthorId\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12!\n" +
+	"\fresume_token\x18\x03 \x01(\tR\vresumeToken\"\xd5\x01\n" +
+	"\tPostEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\x04R\x06postId\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\x04R\bauthorId\x12\x1e\n" +
+	"\x04post\x18\x04 \x01(\v2\n" +
+	".blog.PostR\x04post\x12!\n" +
+	"\fresume_token\x18\x05 \x01(\tR\vresumeToken\x12;\n" +
+	"\voccurred_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"I\n" +
+	"\x11PostActionRequest\x12\x1b\n" +
+	"\auser_id\x18\x01 \x01(\x04B\x02\x18\x01R\x06userId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\x04R\x06postId\"Q\n" +
+	"\rReviewComment\x12\x14\n" +
+	"\x05quote\x18\x01 \x01(\tR\x05quote\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\"\x8d\x01\n" +
+	"\x11ReviewPostRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\x04R\x06postId\x12\x1a\n" +
+	"\bdecision\x18\x02 \x01(\tR\bdecision\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\x12/\n" +
+	"\bcomments\x18\x04 \x03(\v2\x13.blog.ReviewCommentR\bcomments\"\x18\n" +
+	"\x16ListReviewQueueRequest\"w\n" +
+	"\bEditLock\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\x04R\x06postId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"R\n" +
+	"\x10EditLockResponse\x12\x1a\n" +
+	"\bacquired\x18\x01 \x01(\bR\bacquired\x12\"\n" +
+	"\x04lock\x18\x02 \x01(\v2\x0e.blog.EditLockR\x04lock\"d\n" +
+	"\x16ReleaseEditLockRequest\x12\x1b\n" +
+	"\auser_id\x18\x01 \x01(\x04B\x02\x18\x01R\x06userId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\x04R\x06postId\x12\x14\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\"-\n" +
+	"\x12GetEditLockRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\x04R\x06postId\"w\n" +
+	"\x0fAutosaveRequest\x12\x1b\n" +
+	"\auser_id\x18\x01 \x01(\x04B\x02\x18\x01R\x06userId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\x04R\x06postId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\"\x8f\x01\n" +
+	"\x10AutosaveResponse\x12\x14\n" +
+	"\x05saved\x18\x01 \x01(\bR\x05saved\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x125\n" +
+	"\bsaved_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\asavedAt2\xdc\b\n" +
+	"\vBlogService\x129\n" +
+	"\n" +
+	"CreatePost\x12\x17.blog.CreatePostRequest\x1a\x12.blog.BlogResponse\x12+\n" +
+	"\aGetPost\x12\x14.blog.GetPostRequest\x1a\n" +
+	".blog.Post\x121\n" +
+	"\n" +
+	"UpdatePost\x12\x17.blog.UpdatePostRequest\x1a\n" +
+	".blog.Post\x12<\n" +
+	"\tListPosts\x12\x16.blog.ListPostsRequest\x1a\x17.blog.ListPostsResponse\x12A\n" +
+	"\x0eSetTranslation\x12\x1b.blog.SetTranslationRequest\x1a\x12.blog.BlogResponse\x12?\n" +
+	"\n" +
+	"DeletePost\x12\x17.blog.DeletePostRequest\x1a\x18.blog.DeletePostResponse\x128\n" +
+	"\n" +
+	"WatchPosts\x12\x17.blog.WatchPostsRequest\x1a\x0f.blog.PostEvent0\x01\x126\n" +
+	"\x0fSubmitForReview\x12\x17.blog.PostActionRequest\x1a\n" +
+	".blog.Post\x122\n" +
+	"\vPublishPost\x12\x17.blog.PostActionRequest\x1a\n" +
+	".blog.Post\x121\n" +
 	"\n" +
-	"CreatePost\x12\x17.blog.CreatePostRequest\x1a\x12.blog.BlogResponseB\x0eZ\fproto/blogpbb\x06proto3"
+	"ReviewPost\x12\x17.blog.ReviewPostRequest\x1a\n" +
+	".blog.Post\x12H\n" +
+	"\x0fListReviewQueue\x12\x1c.blog.ListReviewQueueRequest\x1a\x17.blog.ListPostsResponse\x12B\n" +
+	"\x0fAcquireEditLock\x12\x17.blog.PostActionRequest\x1a\x16.blog.EditLockResponse\x12@\n" +
+	"\rRenewEditLock\x12\x17.blog.PostActionRequest\x1a\x16.blog.EditLockResponse\x12G\n" +
+	"\x0fReleaseEditLock\x12\x1c.blog.ReleaseEditLockRequest\x1a\x16.blog.EditLockResponse\x12?\n" +
+	"\vGetEditLock\x12\x18.blog.GetEditLockRequest\x1a\x16.blog.EditLockResponse\x129\n" +
+	"\bAutosave\x12\x15.blog.AutosaveRequest\x1a\x16.blog.AutosaveResponse\x12>\n" +
+	"\vGetAutosave\x12\x17.blog.PostActionRequest\x1a\x16.blog.AutosaveResponse\x12B\n" +
+	"\x0fDiscardAutosave\x12\x17.blog.PostActionRequest\x1a\x16.blog.AutosaveResponseB\x0eZ\fproto/blogpbb\x06proto3"
 
 var (
 	file_blog_proto_rawDescOnce sync.Once
@@ -171,19 +1800,87 @@ func file_blog_proto_rawDescGZIP() []byte {
 	return file_blog_proto_rawDescData
 }
 
-var file_blog_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_blog_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_blog_proto_goTypes = []any{
-	(*CreatePostRequest)(nil), // 0: blog.CreatePostRequest
-	(*BlogResponse)(nil),      // 1: blog.BlogResponse
+	(*CreatePostRequest)(nil),      // 0: blog.CreatePostRequest
+	(*BlogResponse)(nil),           // 1: blog.BlogResponse
+	(*Post)(nil),                   // 2: blog.Post
+	(*PostSEO)(nil),                // 3: blog.PostSEO
+	(*GetPostRequest)(nil),         // 4: blog.GetPostRequest
+	(*UpdatePostRequest)(nil),      // 5: blog.UpdatePostRequest
+	(*ListPostsRequest)(nil),       // 6: blog.ListPostsRequest
+	(*ListPostsResponse)(nil),      // 7: blog.ListPostsResponse
+	(*SetTranslationRequest)(nil),  // 8: blog.SetTranslationRequest
+	(*DeletePostRequest)(nil),      // 9: blog.DeletePostRequest
+	(*DeletePostResponse)(nil),     // 10: blog.DeletePostResponse
+	(*WatchPostsRequest)(nil),      // 11: blog.WatchPostsRequest
+	(*PostEvent)(nil),              // 12: blog.PostEvent
+	(*PostActionRequest)(nil),      // 13: blog.PostActionRequest
+	(*ReviewComment)(nil),          // 14: blog.ReviewComment
+	(*ReviewPostRequest)(nil),      // 15: blog.ReviewPostRequest
+	(*ListReviewQueueRequest)(nil), // 16: blog.ListReviewQueueRequest
+	(*EditLock)(nil),               // 17: blog.EditLock
+	(*EditLockResponse)(nil),       // 18: blog.EditLockResponse
+	(*ReleaseEditLockRequest)(nil), // 19: blog.ReleaseEditLockRequest
+	(*GetEditLockRequest)(nil),     // 20: blog.GetEditLockRequest
+	(*AutosaveRequest)(nil),        // 21: blog.AutosaveRequest
+	(*AutosaveResponse)(nil),       // 22: blog.AutosaveResponse
+	(*timestamppb.Timestamp)(nil),  // 23: google.protobuf.Timestamp
 }
 var file_blog_proto_depIdxs = []int32{
-	0, // 0: blog.BlogService.CreatePost:input_type -> blog.CreatePostRequest
-	1, // 1: blog.BlogService.CreatePost:output_type -> blog.BlogResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	23, // 0: blog.Post.published_at:type_name -> google.protobuf.Timestamp
+	23, // 1: blog.Post.created_at:type_name -> google.protobuf.Timestamp
+	23, // 2: blog.Post.updated_at:type_name -> google.protobuf.Timestamp
+	3,  // 3: blog.Post.seo:type_name -> blog.PostSEO
+	3,  // 4: blog.UpdatePostRequest.seo:type_name -> blog.PostSEO
+	2,  // 5: blog.ListPostsResponse.posts:type_name -> blog.Post
+	2,  // 6: blog.PostEvent.post:type_name -> blog.Post
+	23, // 7: blog.PostEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	14, // 8: blog.ReviewPostRequest.comments:type_name -> blog.ReviewComment
+	23, // 9: blog.EditLock.expires_at:type_name -> google.protobuf.Timestamp
+	17, // 10: blog.EditLockResponse.lock:type_name -> blog.EditLock
+	23, // 11: blog.AutosaveResponse.saved_at:type_name -> google.protobuf.Timestamp
+	0,  // 12: blog.BlogService.CreatePost:input_type -> blog.CreatePostRequest
+	4,  // 13: blog.BlogService.GetPost:input_type -> blog.GetPostRequest
+	5,  // 14: blog.BlogService.UpdatePost:input_type -> blog.UpdatePostRequest
+	6,  // 15: blog.BlogService.ListPosts:input_type -> blog.ListPostsRequest
+	8,  // 16: blog.BlogService.SetTranslation:input_type -> blog.SetTranslationRequest
+	9,  // 17: blog.BlogService.DeletePost:input_type -> blog.DeletePostRequest
+	11, // 18: blog.BlogService.WatchPosts:input_type -> blog.WatchPostsRequest
+	13, // 19: blog.BlogService.SubmitForReview:input_type -> blog.PostActionRequest
+	13, // 20: blog.BlogService.PublishPost:input_type -> blog.PostActionRequest
+	15, // 21: blog.BlogService.ReviewPost:input_type -> blog.ReviewPostRequest
+	16, // 22: blog.BlogService.ListReviewQueue:input_type -> blog.ListReviewQueueRequest
+	13, // 23: blog.BlogService.AcquireEditLock:input_type -> blog.PostActionRequest
+	13, // 24: blog.BlogService.RenewEditLock:input_type -> blog.PostActionRequest
+	19, // 25: blog.BlogService.ReleaseEditLock:input_type -> blog.ReleaseEditLockRequest
+	20, // 26: blog.BlogService.GetEditLock:input_type -> blog.GetEditLockRequest
+	21, // 27: blog.BlogService.Autosave:input_type -> blog.AutosaveRequest
+	13, // 28: blog.BlogService.GetAutosave:input_type -> blog.PostActionRequest
+	13, // 29: blog.BlogService.DiscardAutosave:input_type -> blog.PostActionRequest
+	1,  // 30: blog.BlogService.CreatePost:output_type -> blog.BlogResponse
+	2,  // 31: blog.BlogService.GetPost:output_type -> blog.Post
+	2,  // 32: blog.BlogService.UpdatePost:output_type -> blog.Post
+	7,  // 33: blog.BlogService.ListPosts:output_type -> blog.ListPostsResponse
+	1,  // 34: blog.BlogService.SetTranslation:output_type -> blog.BlogResponse
+	10, // 35: blog.BlogService.DeletePost:output_type -> blog.DeletePostResponse
+	12, // 36: blog.BlogService.WatchPosts:output_type -> blog.PostEvent
+	2,  // 37: blog.BlogService.SubmitForReview:output_type -> blog.Post
+	2,  // 38: blog.BlogService.PublishPost:output_type -> blog.Post
+	2,  // 39: blog.BlogService.ReviewPost:output_type -> blog.Post
+	7,  // 40: blog.BlogService.ListReviewQueue:output_type -> blog.ListPostsResponse
+	18, // 41: blog.BlogService.AcquireEditLock:output_type -> blog.EditLockResponse
+	18, // 42: blog.BlogService.RenewEditLock:output_type -> blog.EditLockResponse
+	18, // 43: blog.BlogService.ReleaseEditLock:output_type -> blog.EditLockResponse
+	18, // 44: blog.BlogService.GetEditLock:output_type -> blog.EditLockResponse
+	22, // 45: blog.BlogService.Autosave:output_type -> blog.AutosaveResponse
+	22, // 46: blog.BlogService.GetAutosave:output_type -> blog.AutosaveResponse
+	22, // 47: blog.BlogService.DiscardAutosave:output_type -> blog.AutosaveResponse
+	30, // [30:48] is the sub-list for method output_type
+	12, // [12:30] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_blog_proto_init() }
@@ -191,13 +1888,14 @@ func file_blog_proto_init() {
 	if File_blog_proto != nil {
 		return
 	}
+	file_blog_proto_msgTypes[5].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_blog_proto_rawDesc), len(file_blog_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BlogService_CreatePost_FullMethodName = "/blog.BlogService/CreatePost"
+	BlogService_CreatePost_FullMethodName      = "/blog.BlogService/CreatePost"
+	BlogService_GetPost_FullMethodName         = "/blog.BlogService/GetPost"
+	BlogService_UpdatePost_FullMethodName      = "/blog.BlogService/UpdatePost"
+	BlogService_ListPosts_FullMethodName       = "/blog.BlogService/ListPosts"
+	BlogService_SetTranslation_FullMethodName  = "/blog.BlogService/SetTranslation"
+	BlogService_DeletePost_FullMethodName      = "/blog.BlogService/DeletePost"
+	BlogService_WatchPosts_FullMethodName      = "/blog.BlogService/WatchPosts"
+	BlogService_SubmitForReview_FullMethodName = "/blog.BlogService/SubmitForReview"
+	BlogService_PublishPost_FullMethodName     = "/blog.BlogService/PublishPost"
+	BlogService_ReviewPost_FullMethodName      = "/blog.BlogService/ReviewPost"
+	BlogService_ListReviewQueue_FullMethodName = "/blog.BlogService/ListReviewQueue"
+	BlogService_AcquireEditLock_FullMethodName = "/blog.BlogService/AcquireEditLock"
+	BlogService_RenewEditLock_FullMethodName   = "/blog.BlogService/RenewEditLock"
+	BlogService_ReleaseEditLock_FullMethodName = "/blog.BlogService/ReleaseEditLock"
+	BlogService_GetEditLock_FullMethodName     = "/blog.BlogService/GetEditLock"
+	BlogService_Autosave_FullMethodName        = "/blog.BlogService/Autosave"
+	BlogService_GetAutosave_FullMethodName     = "/blog.BlogService/GetAutosave"
+	BlogService_DiscardAutosave_FullMethodName = "/blog.BlogService/DiscardAutosave"
 )
 
 // BlogServiceClient is the client API for BlogService service.
@@ -27,6 +44,23 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type BlogServiceClient interface {
 	CreatePost(ctx context.Context, in *CreatePostRequest, opts ...grpc.CallOption) (*BlogResponse, error)
+	GetPost(ctx context.Context, in *GetPostRequest, opts ...grpc.CallOption) (*Post, error)
+	UpdatePost(ctx context.Context, in *UpdatePostRequest, opts ...grpc.CallOption) (*Post, error)
+	ListPosts(ctx context.Context, in *ListPostsRequest, opts ...grpc.CallOption) (*ListPostsResponse, error)
+	SetTranslation(ctx context.Context, in *SetTranslationRequest, opts ...grpc.CallOption) (*BlogResponse, error)
+	DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*DeletePostResponse, error)
+	WatchPosts(ctx context.Context, in *WatchPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PostEvent], error)
+	SubmitForReview(ctx context.Context, in *PostActionRequest, opts ...grpc.CallOption) (*Post, error)
+	PublishPost(ctx context.Context, in *PostActionRequest, opts ...grpc.CallOption) (*Post, error)
+	ReviewPost(ctx context.Context, in *ReviewPostRequest, opts ...grpc.CallOption) (*Post, error)
+	ListReviewQueue(ctx context.Context, in *ListReviewQueueRequest, opts ...grpc.CallOption) (*ListPostsResponse, error)
+	AcquireEditLock(ctx context.Context, in *PostActionRequest, opts ...grpc.CallOption) (*EditLockResponse, error)
+	RenewEditLock(ctx context.Context, in *PostActionRequest, opts ...grpc.CallOption) (*EditLockResponse, error)
+	ReleaseEditLock(ctx context.Context, in *ReleaseEditLockRequest, opts ...grpc.CallOption) (*EditLockResponse, error)
+	GetEditLock(ctx context.Context, in *GetEditLockRequest, opts ...grpc.CallOption) (*EditLockResponse, error)
+	Autosave(ctx context.Context, in *AutosaveRequest, opts ...grpc.CallOption) (*AutosaveResponse, error)
+	GetAutosave(ctx context.Context, in *PostActionRequest, opts ...grpc.CallOption) (*AutosaveResponse, error)
+	DiscardAutosave(ctx context.Context, in *PostActionRequest, opts ...grpc.CallOption) (*AutosaveResponse, error)
 }
 
 type blogServiceClient struct {
@@ -47,11 +81,207 @@ func (c *blogServiceClient) CreatePost(ctx context.Context, in *CreatePostReques
 	return out, nil
 }
 
+func (c *blogServiceClient) GetPost(ctx context.Context, in *GetPostRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, BlogService_GetPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) UpdatePost(ctx context.Context, in *UpdatePostRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, BlogService_UpdatePost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListPosts(ctx context.Context, in *ListPostsRequest, opts ...grpc.CallOption) (*ListPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPostsResponse)
+	err := c.cc.Invoke(ctx, BlogService_ListPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) SetTranslation(ctx context.Context, in *SetTranslationRequest, opts ...grpc.CallOption) (*BlogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlogResponse)
+	err := c.cc.Invoke(ctx, BlogService_SetTranslation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*DeletePostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePostResponse)
+	err := c.cc.Invoke(ctx, BlogService_DeletePost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) WatchPosts(ctx context.Context, in *WatchPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PostEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BlogService_ServiceDesc.Streams[0], BlogService_WatchPosts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchPostsRequest, PostEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlogService_WatchPostsClient = grpc.ServerStreamingClient[PostEvent]
+
+func (c *blogServiceClient) SubmitForReview(ctx context.Context, in *PostActionRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, BlogService_SubmitForReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) PublishPost(ctx context.Context, in *PostActionRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, BlogService_PublishPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ReviewPost(ctx context.Context, in *ReviewPostRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, BlogService_ReviewPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListReviewQueue(ctx context.Context, in *ListReviewQueueRequest, opts ...grpc.CallOption) (*ListPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPostsResponse)
+	err := c.cc.Invoke(ctx, BlogService_ListReviewQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) AcquireEditLock(ctx context.Context, in *PostActionRequest, opts ...grpc.CallOption) (*EditLockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditLockResponse)
+	err := c.cc.Invoke(ctx, BlogService_AcquireEditLock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) RenewEditLock(ctx context.Context, in *PostActionRequest, opts ...grpc.CallOption) (*EditLockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditLockResponse)
+	err := c.cc.Invoke(ctx, BlogService_RenewEditLock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ReleaseEditLock(ctx context.Context, in *ReleaseEditLockRequest, opts ...grpc.CallOption) (*EditLockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditLockResponse)
+	err := c.cc.Invoke(ctx, BlogService_ReleaseEditLock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) GetEditLock(ctx context.Context, in *GetEditLockRequest, opts ...grpc.CallOption) (*EditLockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditLockResponse)
+	err := c.cc.Invoke(ctx, BlogService_GetEditLock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) Autosave(ctx context.Context, in *AutosaveRequest, opts ...grpc.CallOption) (*AutosaveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AutosaveResponse)
+	err := c.cc.Invoke(ctx, BlogService_Autosave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) GetAutosave(ctx context.Context, in *PostActionRequest, opts ...grpc.CallOption) (*AutosaveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AutosaveResponse)
+	err := c.cc.Invoke(ctx, BlogService_GetAutosave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) DiscardAutosave(ctx context.Context, in *PostActionRequest, opts ...grpc.CallOption) (*AutosaveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AutosaveResponse)
+	err := c.cc.Invoke(ctx, BlogService_DiscardAutosave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BlogServiceServer is the server API for BlogService service.
 // All implementations must embed UnimplementedBlogServiceServer
 // for forward compatibility.
 type BlogServiceServer interface {
 	CreatePost(context.Context, *CreatePostRequest) (*BlogResponse, error)
+	GetPost(context.Context, *GetPostRequest) (*Post, error)
+	UpdatePost(context.Context, *UpdatePostRequest) (*Post, error)
+	ListPosts(context.Context, *ListPostsRequest) (*ListPostsResponse, error)
+	SetTranslation(context.Context, *SetTranslationRequest) (*BlogResponse, error)
+	DeletePost(context.Context, *DeletePostRequest) (*DeletePostResponse, error)
+	WatchPosts(*WatchPostsRequest, grpc.ServerStreamingServer[PostEvent]) error
+	SubmitForReview(context.Context, *PostActionRequest) (*Post, error)
+	PublishPost(context.Context, *PostActionRequest) (*Post, error)
+	ReviewPost(context.Context, *ReviewPostRequest) (*Post, error)
+	ListReviewQueue(context.Context, *ListReviewQueueRequest) (*ListPostsResponse, error)
+	AcquireEditLock(context.Context, *PostActionRequest) (*EditLockResponse, error)
+	RenewEditLock(context.Context, *PostActionRequest) (*EditLockResponse, error)
+	ReleaseEditLock(context.Context, *ReleaseEditLockRequest) (*EditLockResponse, error)
+	GetEditLock(context.Context, *GetEditLockRequest) (*EditLockResponse, error)
+	Autosave(context.Context, *AutosaveRequest) (*AutosaveResponse, error)
+	GetAutosave(context.Context, *PostActionRequest) (*AutosaveResponse, error)
+	DiscardAutosave(context.Context, *PostActionRequest) (*AutosaveResponse, error)
 	mustEmbedUnimplementedBlogServiceServer()
 }
 
@@ -65,6 +295,57 @@ type UnimplementedBlogServiceServer struct{}
 func (UnimplementedBlogServiceServer) CreatePost(context.Context, *CreatePostRequest) (*BlogResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreatePost not implemented")
 }
+func (UnimplementedBlogServiceServer) GetPost(context.Context, *GetPostRequest) (*Post, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPost not implemented")
+}
+func (UnimplementedBlogServiceServer) UpdatePost(context.Context, *UpdatePostRequest) (*Post, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdatePost not implemented")
+}
+func (UnimplementedBlogServiceServer) ListPosts(context.Context, *ListPostsRequest) (*ListPostsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPosts not implemented")
+}
+func (UnimplementedBlogServiceServer) SetTranslation(context.Context, *SetTranslationRequest) (*BlogResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTranslation not implemented")
+}
+func (UnimplementedBlogServiceServer) DeletePost(context.Context, *DeletePostRequest) (*DeletePostResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeletePost not implemented")
+}
+func (UnimplementedBlogServiceServer) WatchPosts(*WatchPostsRequest, grpc.ServerStreamingServer[PostEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchPosts not implemented")
+}
+func (UnimplementedBlogServiceServer) SubmitForReview(context.Context, *PostActionRequest) (*Post, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitForReview not implemented")
+}
+func (UnimplementedBlogServiceServer) PublishPost(context.Context, *PostActionRequest) (*Post, error) {
+	return nil, status.Error(codes.Unimplemented, "method PublishPost not implemented")
+}
+func (UnimplementedBlogServiceServer) ReviewPost(context.Context, *ReviewPostRequest) (*Post, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReviewPost not implemented")
+}
+func (UnimplementedBlogServiceServer) ListReviewQueue(context.Context, *ListReviewQueueRequest) (*ListPostsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListReviewQueue not implemented")
+}
+func (UnimplementedBlogServiceServer) AcquireEditLock(context.Context, *PostActionRequest) (*EditLockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcquireEditLock not implemented")
+}
+func (UnimplementedBlogServiceServer) RenewEditLock(context.Context, *PostActionRequest) (*EditLockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenewEditLock not implemented")
+}
+func (UnimplementedBlogServiceServer) ReleaseEditLock(context.Context, *ReleaseEditLockRequest) (*EditLockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReleaseEditLock not implemented")
+}
+func (UnimplementedBlogServiceServer) GetEditLock(context.Context, *GetEditLockRequest) (*EditLockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetEditLock not implemented")
+}
+func (UnimplementedBlogServiceServer) Autosave(context.Context, *AutosaveRequest) (*AutosaveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Autosave not implemented")
+}
+func (UnimplementedBlogServiceServer) GetAutosave(context.Context, *PostActionRequest) (*AutosaveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAutosave not implemented")
+}
+func (UnimplementedBlogServiceServer) DiscardAutosave(context.Context, *PostActionRequest) (*AutosaveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiscardAutosave not implemented")
+}
 func (UnimplementedBlogServiceServer) mustEmbedUnimplementedBlogServiceServer() {}
 func (UnimplementedBlogServiceServer) testEmbeddedByValue()                     {}
 
@@ -104,6 +385,305 @@ func _BlogService_CreatePost_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlogService_GetPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).GetPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_GetPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).GetPost(ctx, req.(*GetPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_UpdatePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).UpdatePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_UpdatePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).UpdatePost(ctx, req.(*UpdatePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ListPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ListPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_ListPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ListPosts(ctx, req.(*ListPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_SetTranslation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTranslationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).SetTranslation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_SetTranslation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).SetTranslation(ctx, req.(*SetTranslationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_DeletePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).DeletePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_DeletePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).DeletePost(ctx, req.(*DeletePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_WatchPosts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPostsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServiceServer).WatchPosts(m, &grpc.GenericServerStream[WatchPostsRequest, PostEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlogService_WatchPostsServer = grpc.ServerStreamingServer[PostEvent]
+
+func _BlogService_SubmitForReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).SubmitForReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_SubmitForReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).SubmitForReview(ctx, req.(*PostActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_PublishPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).PublishPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_PublishPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).PublishPost(ctx, req.(*PostActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ReviewPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ReviewPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_ReviewPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ReviewPost(ctx, req.(*ReviewPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ListReviewQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ListReviewQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_ListReviewQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ListReviewQueue(ctx, req.(*ListReviewQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_AcquireEditLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).AcquireEditLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_AcquireEditLock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).AcquireEditLock(ctx, req.(*PostActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_RenewEditLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).RenewEditLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_RenewEditLock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).RenewEditLock(ctx, req.(*PostActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ReleaseEditLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseEditLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ReleaseEditLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_ReleaseEditLock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ReleaseEditLock(ctx, req.(*ReleaseEditLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_GetEditLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEditLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).GetEditLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_GetEditLock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).GetEditLock(ctx, req.(*GetEditLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_Autosave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AutosaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).Autosave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_Autosave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).Autosave(ctx, req.(*AutosaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_GetAutosave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).GetAutosave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_GetAutosave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).GetAutosave(ctx, req.(*PostActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_DiscardAutosave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).DiscardAutosave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_DiscardAutosave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).DiscardAutosave(ctx, req.(*PostActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BlogService_ServiceDesc is the grpc.ServiceDesc for BlogService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,7 +695,77 @@ var BlogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreatePost",
 			Handler:    _BlogService_CreatePost_Handler,
 		},
+		{
+			MethodName: "GetPost",
+			Handler:    _BlogService_GetPost_Handler,
+		},
+		{
+			MethodName: "UpdatePost",
+			Handler:    _BlogService_UpdatePost_Handler,
+		},
+		{
+			MethodName: "ListPosts",
+			Handler:    _BlogService_ListPosts_Handler,
+		},
+		{
+			MethodName: "SetTranslation",
+			Handler:    _BlogService_SetTranslation_Handler,
+		},
+		{
+			MethodName: "DeletePost",
+			Handler:    _BlogService_DeletePost_Handler,
+		},
+		{
+			MethodName: "SubmitForReview",
+			Handler:    _BlogService_SubmitForReview_Handler,
+		},
+		{
+			MethodName: "PublishPost",
+			Handler:    _BlogService_PublishPost_Handler,
+		},
+		{
+			MethodName: "ReviewPost",
+			Handler:    _BlogService_ReviewPost_Handler,
+		},
+		{
+			MethodName: "ListReviewQueue",
+			Handler:    _BlogService_ListReviewQueue_Handler,
+		},
+		{
+			MethodName: "AcquireEditLock",
+			Handler:    _BlogService_AcquireEditLock_Handler,
+		},
+		{
+			MethodName: "RenewEditLock",
+			Handler:    _BlogService_RenewEditLock_Handler,
+		},
+		{
+			MethodName: "ReleaseEditLock",
+			Handler:    _BlogService_ReleaseEditLock_Handler,
+		},
+		{
+			MethodName: "GetEditLock",
+			Handler:    _BlogService_GetEditLock_Handler,
+		},
+		{
+			MethodName: "Autosave",
+			Handler:    _BlogService_Autosave_Handler,
+		},
+		{
+			MethodName: "GetAutosave",
+			Handler:    _BlogService_GetAutosave_Handler,
+		},
+		{
+			MethodName: "DiscardAutosave",
+			Handler:    _BlogService_DiscardAutosave_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPosts",
+			Handler:       _BlogService_WatchPosts_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "blog.proto",
 }